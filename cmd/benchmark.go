@@ -0,0 +1,443 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/olekukonko/tablewriter"
+	hubtypes "github.com/sentinel-official/hub/types"
+	nodetypes "github.com/sentinel-official/hub/x/node/types"
+	subscriptiontypes "github.com/sentinel-official/hub/x/subscription/types"
+	"github.com/spf13/cobra"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	netutil "github.com/sentinel-official/cli-client/utils/net"
+	nodeapi "github.com/sentinel-official/cli-client/x/node/api"
+	nodeinfotypes "github.com/sentinel-official/cli-client/x/node/types"
+)
+
+const (
+	flagSamples            = "samples"
+	flagSubscription       = "subscription"
+	flagThroughput         = "throughput"
+	flagThroughputDuration = "throughput.duration"
+	flagThroughputURL      = "throughput.url"
+
+	benchmarkConnectionName = "benchmark"
+	sampleInterval          = 200 * time.Millisecond
+)
+
+var (
+	benchmarkHeader = []string{
+		"Address",
+		"Samples",
+		"Lost",
+		"Min",
+		"Avg",
+		"Jitter",
+		"Throughput",
+		"Measured at",
+		"Error",
+	}
+)
+
+func benchmarkRow(item nodeinfotypes.Benchmark) []string {
+	throughput := ""
+	if item.Throughput > 0 {
+		throughput = netutil.ToReadable(item.Throughput, 2) + "/s"
+	}
+
+	return []string{
+		item.Address,
+		fmt.Sprintf("%d", item.Samples),
+		fmt.Sprintf("%d", item.Lost),
+		item.Min.Truncate(time.Microsecond).String(),
+		item.Avg.Truncate(time.Microsecond).String(),
+		item.Jitter.Truncate(time.Microsecond).String(),
+		throughput,
+		item.At.Format(time.RFC3339),
+		item.Error,
+	}
+}
+
+// measureRTT returns the times taken for connecting to the address over TCP,
+// with zero for the failed samples.
+func measureRTT(ctx context.Context, address string, samples int, timeout time.Duration) []time.Duration {
+	var (
+		dialer = &net.Dialer{Timeout: timeout}
+		rtts   = make([]time.Duration, 0, samples)
+	)
+
+	for i := 0; i < samples; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return rtts
+			case <-time.After(sampleInterval):
+			}
+		}
+
+		start := time.Now()
+
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			rtts = append(rtts, 0)
+			continue
+		}
+
+		rtts = append(rtts, time.Since(start))
+		_ = conn.Close()
+	}
+
+	return rtts
+}
+
+// measureThroughput downloads from the URL for at most the duration, through
+// the proxy if given, and returns the bytes per second.
+func measureThroughput(ctx context.Context, rawURL string, duration time.Duration, proxy *url.URL) (int64, error) {
+	c, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	transport := &http.Transport{}
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	defer transport.CloseIdleConnections()
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status %s from %s", resp.Status, rawURL)
+	}
+
+	n, err := io.Copy(io.Discard, resp.Body)
+	if err != nil && c.Err() == nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, errors.New("nothing was downloaded")
+	}
+
+	return int64(float64(n) / time.Since(start).Seconds()), nil
+}
+
+// endBenchmarkConnection disconnects and ends the session, also of a failed connect.
+func endBenchmarkConnection(cmd *cobra.Command, ctx client.Context, name string) error {
+	lock, err := acquireLock(ctx.HomeDir)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = lock.Release() }()
+
+	status, err := loadStatus(ctx.HomeDir, name)
+	if err != nil {
+		return err
+	}
+	if status.Session != 0 {
+		if err = disconnect(context.Background(), ctx.HomeDir, name, false); err != nil {
+			return err
+		}
+
+		return endSession(cmd, ctx, status.Session)
+	}
+
+	hs, err := loadHandshake(ctx.HomeDir, name)
+	if err != nil || hs == nil {
+		return err
+	}
+	if hs.Session != 0 {
+		cmd.PrintErrf("Ending the session %d of the failed connect\n", hs.Session)
+		if err = endSession(cmd, ctx, hs.Session); err != nil {
+			return err
+		}
+	}
+
+	return removeHandshake(ctx.HomeDir, name)
+}
+
+// benchmarkThroughput connects to the node, measures the throughput through
+// the tunnel and disconnects again, ending the session.
+func benchmarkThroughput(
+	cmd *cobra.Command, ctx client.Context, id uint64, address hubtypes.NodeAddress, rawURL string, duration time.Duration,
+) (int64, error) {
+	name, err := cmd.Flags().GetString(clienttypes.FlagName)
+	if err != nil {
+		return 0, err
+	}
+
+	if id == 0 {
+		id, err = findSubscription(ctx, subscriptiontypes.NewQueryServiceClient(ctx), address)
+		if err != nil {
+			return 0, err
+		}
+		if id == 0 {
+			return 0, fmt.Errorf("no active subscription of %s can be used for the node", ctx.FromAddress)
+		}
+	}
+
+	// The default port of the V2Ray proxy may be taken by another connection
+	if !cmd.Flags().Changed(clienttypes.FlagV2RayProxyPort) {
+		port, err := netutil.GetFreeTCPPort()
+		if err != nil {
+			return 0, err
+		}
+		if err = cmd.Flags().Set(clienttypes.FlagV2RayProxyPort, strconv.Itoa(int(port))); err != nil {
+			return 0, err
+		}
+	}
+
+	// The session is paid for once started, so it is ended on any failure
+	defer func() {
+		if err := endBenchmarkConnection(cmd, ctx, name); err != nil {
+			cmd.PrintErrf("Failed to disconnect %s: %s\n", name, err)
+		}
+	}()
+
+	if err = runConnect(cmd, []string{strconv.FormatUint(id, 10), address.String()}); err != nil {
+		return 0, err
+	}
+
+	status, err := loadStatus(ctx.HomeDir, name)
+	if err != nil {
+		return 0, err
+	}
+
+	service, err := newServiceFromStatus(status)
+	if err != nil {
+		return 0, err
+	}
+
+	var proxy *url.URL
+	if v, ok := service.(clienttypes.Proxied); ok {
+		proxy = v.ProxyURL()
+	}
+
+	return measureThroughput(cmd.Context(), rawURL, duration, proxy)
+}
+
+func nodesBenchmarkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "benchmark [address...]",
+		Short: "Measure the round trip time and optionally the throughput of the nodes, or of the favourite nodes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			apiConfig, err := nodeapi.NewConfigFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
+			samples, err := cmd.Flags().GetInt(flagSamples)
+			if err != nil {
+				return err
+			}
+			if samples < 1 {
+				return fmt.Errorf("invalid number of samples %d", samples)
+			}
+
+			throughput, err := cmd.Flags().GetBool(flagThroughput)
+			if err != nil {
+				return err
+			}
+
+			throughputURL, err := cmd.Flags().GetString(flagThroughputURL)
+			if err != nil {
+				return err
+			}
+
+			throughputDuration, err := cmd.Flags().GetDuration(flagThroughputDuration)
+			if err != nil {
+				return err
+			}
+
+			id, err := cmd.Flags().GetUint64(flagSubscription)
+			if err != nil {
+				return err
+			}
+
+			if throughput {
+				for _, name := range []string{clienttypes.FlagDryRun, clienttypes.FlagResume, clienttypes.FlagWait} {
+					if v, _ := cmd.Flags().GetBool(name); v {
+						return fmt.Errorf("--%s cannot be used with --%s", name, flagThroughput)
+					}
+				}
+
+				limits, err := readLimits(cmd)
+				if err != nil {
+					return err
+				}
+				if !limits.isZero() {
+					return fmt.Errorf("limits cannot be used with --%s", flagThroughput)
+				}
+
+				name, err := cmd.Flags().GetString(clienttypes.FlagName)
+				if err != nil {
+					return err
+				}
+
+				// Two tunnels routing all the traffic conflict with each other
+				other, err := fullTunnelConnection(ctx.HomeDir, name)
+				if err != nil {
+					return err
+				}
+				if other != "" {
+					return fmt.Errorf("connection %s routes all the traffic; disconnect it before measuring the throughput", other)
+				}
+			}
+
+			if len(args) == 0 {
+				favorites := clienttypes.NewFavorites(ctx.HomeDir)
+				if err = favorites.Load(); err != nil {
+					return err
+				}
+
+				blocked := clienttypes.NewBlocked(ctx.HomeDir)
+				if err = blocked.Load(); err != nil {
+					return err
+				}
+
+				for _, item := range favorites.List() {
+					if !blocked.Has(item.Address) {
+						args = append(args, item.Address)
+					}
+				}
+				if len(args) == 0 {
+					return errors.New("no nodes given and no favourite nodes")
+				}
+			}
+
+			store := nodeinfotypes.NewBenchmarkStore(ctx.HomeDir)
+			if err = store.Load(); err != nil {
+				return err
+			}
+
+			var (
+				qsc     = nodetypes.NewQueryServiceClient(ctx)
+				results []nodeinfotypes.Benchmark
+			)
+
+			for _, arg := range args {
+				address, err := hubtypes.NodeAddressFromBech32(arg)
+				if err != nil {
+					return err
+				}
+
+				result := nodeinfotypes.NewBenchmark(address.String(), nil)
+
+				node, err := queryNode(qsc, address)
+				if err != nil {
+					result.Error = err.Error()
+				} else if remote, err := remoteAddress(node.RemoteURL); err != nil {
+					result.Error = err.Error()
+				} else {
+					result = nodeinfotypes.NewBenchmark(
+						address.String(),
+						measureRTT(cmd.Context(), remote, samples, apiConfig.DialTimeout),
+					)
+
+					switch {
+					case result.Lost == result.Samples:
+						result.Error = fmt.Sprintf("%s is not reachable", remote)
+					case throughput:
+						result.Throughput, err = benchmarkThroughput(cmd, ctx, id, address, throughputURL, throughputDuration)
+						if err != nil {
+							result.Error = err.Error()
+						}
+					}
+				}
+
+				if cmd.Context().Err() != nil {
+					break
+				}
+
+				store.Add(result)
+				results = append(results, result)
+			}
+
+			if err = store.Save(); err != nil {
+				return err
+			}
+
+			table := tablewriter.NewWriter(cmd.OutOrStdout())
+			table.SetHeader(benchmarkHeader)
+
+			for _, item := range results {
+				table.Append(benchmarkRow(item))
+			}
+
+			table.Render()
+			return nil
+		},
+	}
+
+	addConnectFlags(cmd)
+
+	// The tunnels for measuring the throughput do not replace the usual connection
+	if f := cmd.Flags().Lookup(clienttypes.FlagName); f != nil {
+		f.DefValue = benchmarkConnectionName
+		_ = f.Value.Set(benchmarkConnectionName)
+	}
+
+	cmd.Flags().Int(flagSamples, 5, "number of the round trip time samples")
+	cmd.Flags().Bool(flagThroughput, false, "connect to each of the nodes and measure the throughput through the tunnel")
+	cmd.Flags().String(flagThroughputURL, "https://speed.cloudflare.com/__down?bytes=25000000", "URL downloaded for measuring the throughput")
+	cmd.Flags().Duration(flagThroughputDuration, 10*time.Second, "time limit for measuring the throughput")
+	cmd.Flags().Uint64(flagSubscription, 0, "subscription used for measuring the throughput, found for each node if not given")
+
+	return cmd
+}
+
+func nodesBenchmarksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "benchmarks",
+		Short: "List the latest benchmark of each node, the best ranked first",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			store := nodeinfotypes.NewBenchmarkStore(ctx.HomeDir)
+			if err = store.Load(); err != nil {
+				return err
+			}
+
+			table := tablewriter.NewWriter(cmd.OutOrStdout())
+			table.SetHeader(benchmarkHeader)
+
+			for _, item := range store.Latest() {
+				table.Append(benchmarkRow(item))
+			}
+
+			table.Render()
+			return nil
+		},
+	}
+
+	return cmd
+}
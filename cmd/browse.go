@@ -0,0 +1,893 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	hubtypes "github.com/sentinel-official/hub/types"
+	nodetypes "github.com/sentinel-official/hub/x/node/types"
+	subscriptiontypes "github.com/sentinel-official/hub/x/subscription/types"
+	"github.com/spf13/cobra"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	nodeapi "github.com/sentinel-official/cli-client/x/node/api"
+	nodeinfotypes "github.com/sentinel-official/cli-client/x/node/types"
+)
+
+const (
+	flagPlanID = "plan-id"
+
+	browseConcurrency   = 16
+	browseSortBy        = 3 // Latency
+	browseProbeInterval = 30 * time.Second
+	browseHelp          = "↑/↓ move  enter details  c connect  f favourite  F favourites only  b block  / location  " +
+		"t type  g/h max price  s sort  r reverse  S subscription  q quit"
+)
+
+type browseItem struct {
+	node     nodeinfotypes.Node
+	probed   bool
+	err      error
+	favorite bool
+}
+
+func (i *browseItem) reachable() bool { return i.probed && i.err == nil }
+
+type browseColumn struct {
+	name  string
+	width int
+	value func(b *browser, i *browseItem) string
+	less  func(b *browser, x, y *browseItem) bool
+}
+
+// price returns the amount of the denom of the price filter in the prices,
+// or -1 if there is none.
+func (b *browser) price(prices clienttypes.Coins) int64 {
+	for _, coin := range prices {
+		if coin.Denom == b.denom {
+			return coin.Value
+		}
+	}
+
+	return -1
+}
+
+func lessPrice(x, y int64) bool {
+	if x < 0 || y < 0 {
+		return y < 0 && x >= 0
+	}
+
+	return x < y
+}
+
+var (
+	browseColumns = []browseColumn{
+		{
+			name:  "Moniker",
+			width: 24,
+			value: func(_ *browser, i *browseItem) string { return i.node.Moniker },
+			less: func(_ *browser, x, y *browseItem) bool {
+				return strings.ToLower(x.node.Moniker) < strings.ToLower(y.node.Moniker)
+			},
+		},
+		{
+			name:  "Location",
+			width: 24,
+			value: func(_ *browser, i *browseItem) string {
+				if i.node.Location.City == "" {
+					return i.node.Location.Country
+				}
+
+				return i.node.Location.City + ", " + i.node.Location.Country
+			},
+			less: func(_ *browser, x, y *browseItem) bool {
+				if x.node.Location.Country != y.node.Location.Country {
+					return x.node.Location.Country < y.node.Location.Country
+				}
+
+				return x.node.Location.City < y.node.Location.City
+			},
+		},
+		{
+			name:  "Type",
+			width: 10,
+			value: func(_ *browser, i *browseItem) string {
+				if !i.reachable() {
+					return ""
+				}
+
+				return clienttypes.ServiceName(i.node.Type)
+			},
+			less: func(_ *browser, x, y *browseItem) bool { return x.node.Type < y.node.Type },
+		},
+		{
+			name:  "Latency",
+			width: 10,
+			value: func(_ *browser, i *browseItem) string {
+				switch {
+				case !i.probed:
+					return "..."
+				case i.err != nil:
+					return "down"
+				default:
+					return i.node.Latency.Truncate(time.Millisecond).String()
+				}
+			},
+			less: func(_ *browser, x, y *browseItem) bool {
+				if !x.reachable() || !y.reachable() {
+					return x.reachable() && !y.reachable()
+				}
+
+				return x.node.Latency < y.node.Latency
+			},
+		},
+		{
+			name:  "Gigabyte prices",
+			width: 24,
+			value: func(_ *browser, i *browseItem) string { return i.node.GigabytePrices.Raw().String() },
+			less: func(b *browser, x, y *browseItem) bool {
+				return lessPrice(b.price(x.node.GigabytePrices), b.price(y.node.GigabytePrices))
+			},
+		},
+		{
+			name:  "Hourly prices",
+			width: 24,
+			value: func(_ *browser, i *browseItem) string { return i.node.HourlyPrices.Raw().String() },
+			less: func(b *browser, x, y *browseItem) bool {
+				return lessPrice(b.price(x.node.HourlyPrices), b.price(y.node.HourlyPrices))
+			},
+		},
+		{
+			name:  "Peers",
+			width: 6,
+			value: func(_ *browser, i *browseItem) string { return fmt.Sprintf("%d", i.node.Peers) },
+			less:  func(_ *browser, x, y *browseItem) bool { return x.node.Peers < y.node.Peers },
+		},
+		{
+			name:  "Version",
+			width: 10,
+			value: func(_ *browser, i *browseItem) string { return i.node.Version },
+			less:  func(_ *browser, x, y *browseItem) bool { return x.node.Version < y.node.Version },
+		},
+	}
+)
+
+// prompt is a line of input asked for at the bottom of the screen.
+type prompt struct {
+	label string
+	input []rune
+	done  func(value string)
+}
+
+type browser struct {
+	mutex sync.Mutex
+	items []*browseItem
+	view  []*browseItem
+
+	cursor  int
+	offset  int
+	sortBy  int
+	reverse bool
+	details bool
+	prompt  *prompt
+	message string
+
+	location      string
+	nodeType      uint64
+	denom         string
+	maxGigabyte   int64
+	maxHourly     int64
+	favoritesOnly bool
+
+	subscription uint64
+	selected     *browseItem
+	favorites    *clienttypes.NodeList
+	blocked      *clienttypes.NodeList
+	cache        *nodeinfotypes.Cache
+}
+
+func (b *browser) match(i *browseItem) bool {
+	if b.favoritesOnly && !i.favorite {
+		return false
+	}
+	if b.location != "" {
+		s := strings.ToLower(i.node.Location.Country + " " + i.node.Location.City)
+		if !strings.Contains(s, strings.ToLower(b.location)) {
+			return false
+		}
+	}
+	if b.nodeType != 0 && (!i.reachable() || i.node.Type != b.nodeType) {
+		return false
+	}
+	if b.maxGigabyte > 0 {
+		if v := b.price(i.node.GigabytePrices); v < 0 || v > b.maxGigabyte {
+			return false
+		}
+	}
+	if b.maxHourly > 0 {
+		if v := b.price(i.node.HourlyPrices); v < 0 || v > b.maxHourly {
+			return false
+		}
+	}
+
+	return true
+}
+
+// refresh applies the filters and the sorting to the items, keeping the
+// cursor on the same node if it is still shown.
+func (b *browser) refresh() {
+	var current *browseItem
+	if b.cursor < len(b.view) {
+		current = b.view[b.cursor]
+	}
+
+	b.view = b.view[:0]
+	for _, item := range b.items {
+		if b.match(item) {
+			b.view = append(b.view, item)
+		}
+	}
+
+	column := browseColumns[b.sortBy]
+	sort.SliceStable(b.view, func(i, j int) bool {
+		if b.reverse {
+			return column.less(b, b.view[j], b.view[i])
+		}
+
+		return column.less(b, b.view[i], b.view[j])
+	})
+
+	b.cursor = 0
+	for i, item := range b.view {
+		if item == current {
+			b.cursor = i
+		}
+	}
+}
+
+func (b *browser) filters() string {
+	var items []string
+	if b.location != "" {
+		items = append(items, "location "+b.location)
+	}
+	if b.nodeType != 0 {
+		items = append(items, "type "+clienttypes.ServiceName(b.nodeType))
+	}
+	if b.maxGigabyte > 0 {
+		items = append(items, fmt.Sprintf("≤ %d%s/GB", b.maxGigabyte, b.denom))
+	}
+	if b.maxHourly > 0 {
+		items = append(items, fmt.Sprintf("≤ %d%s/h", b.maxHourly, b.denom))
+	}
+	if b.favoritesOnly {
+		items = append(items, "favourites")
+	}
+	if len(items) == 0 {
+		return "none"
+	}
+
+	return strings.Join(items, ", ")
+}
+
+func (b *browser) lines(height int) []string {
+	order := "↑"
+	if b.reverse {
+		order = "↓"
+	}
+
+	subscription := "auto"
+	if b.subscription != 0 {
+		subscription = fmt.Sprintf("%d", b.subscription)
+	}
+
+	lines := []string{
+		fmt.Sprintf("Nodes %d of %d | filters: %s | sort: %s %s | subscription: %s",
+			len(b.view), len(b.items), b.filters(), browseColumns[b.sortBy].name, order, subscription),
+	}
+
+	if b.details && b.cursor < len(b.view) {
+		lines = append(lines, "")
+		lines = append(lines, b.detailLines(b.view[b.cursor])...)
+	} else {
+		header := "  "
+		for _, column := range browseColumns {
+			header += pad(column.name, column.width) + " "
+		}
+
+		lines = append(lines, header)
+
+		rows := height - 4
+		if rows < 1 {
+			rows = 1
+		}
+		if b.cursor < b.offset {
+			b.offset = b.cursor
+		}
+		if b.cursor >= b.offset+rows {
+			b.offset = b.cursor - rows + 1
+		}
+
+		for i := b.offset; i < len(b.view) && i < b.offset+rows; i++ {
+			line := "  "
+			if b.view[i].favorite {
+				line = " *"
+			}
+			for _, column := range browseColumns {
+				line += pad(column.value(b, b.view[i]), column.width) + " "
+			}
+			if i == b.cursor {
+				line = "\x1b[7m" + line + "\x1b[0m"
+			}
+
+			lines = append(lines, line)
+		}
+	}
+
+	for len(lines) < height-2 {
+		lines = append(lines, "")
+	}
+
+	switch {
+	case b.prompt != nil:
+		lines = append(lines, "", b.prompt.label+string(b.prompt.input)+"_")
+	default:
+		lines = append(lines, b.message, browseHelp)
+	}
+
+	return lines
+}
+
+func (b *browser) detailLines(i *browseItem) []string {
+	n := i.node
+	lines := []string{
+		"Moniker:          " + n.Moniker,
+		"Address:          " + n.Address,
+		"Operator:         " + n.Operator,
+		"Remote URL:       " + n.RemoteURL,
+		"Status:           " + n.Status + " since " + n.StatusAt.Format(time.RFC3339),
+		fmt.Sprintf("Location:         %s, %s (%.4f, %.4f)", n.Location.City, n.Location.Country, n.Location.Latitude, n.Location.Longitude),
+		"Type:             " + clienttypes.ServiceName(n.Type),
+		"Version:          " + n.Version,
+		"Speed test:       " + n.Bandwidth.String(),
+		fmt.Sprintf("Handshake:        %t, %d peers", n.Handshake.Enable, n.Handshake.Peers),
+		fmt.Sprintf("Peers:            %d", n.Peers),
+		"Latency:          " + n.Latency.Truncate(time.Millisecond).String(),
+		"Gigabyte prices:  " + n.GigabytePrices.Raw().String(),
+		"Hourly prices:    " + n.HourlyPrices.Raw().String(),
+		fmt.Sprintf("Favourite:        %t", i.favorite),
+	}
+
+	if i.err != nil {
+		lines = append(lines, "Error:            "+i.err.Error())
+	}
+
+	return lines
+}
+
+func (b *browser) ask(label string, done func(value string)) {
+	b.prompt = &prompt{label: label, done: done}
+}
+
+func (b *browser) setMaxPrice(value string, limit *int64) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		*limit = 0
+		return
+	}
+
+	coin, err := sdk.ParseCoinNormalized(value)
+	if err != nil {
+		b.message = fmt.Sprintf("Invalid price %s: %s", value, err)
+		return
+	}
+
+	if !coin.Amount.IsInt64() {
+		b.message = fmt.Sprintf("Price %s is too large", value)
+		return
+	}
+
+	b.denom, *limit = coin.Denom, coin.Amount.Int64()
+}
+
+func (b *browser) cycleType() {
+	services := clienttypes.Services()
+	if b.nodeType == 0 {
+		if len(services) > 0 {
+			b.nodeType = services[0].Type
+		}
+
+		return
+	}
+
+	for i, definition := range services {
+		if definition.Type == b.nodeType {
+			if i+1 < len(services) {
+				b.nodeType = services[i+1].Type
+			} else {
+				b.nodeType = 0
+			}
+
+			return
+		}
+	}
+
+	b.nodeType = 0
+}
+
+// handle applies a key press and returns whether the browser has to exit.
+func (b *browser) handle(k key, height int) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if p := b.prompt; p != nil {
+		switch k.code {
+		case keyEnter:
+			b.prompt = nil
+			p.done(string(p.input))
+		case keyEscape:
+			b.prompt = nil
+		case keyBackspace:
+			if len(p.input) > 0 {
+				p.input = p.input[:len(p.input)-1]
+			}
+		case keyInterrupt:
+			return true
+		case keyRune:
+			p.input = append(p.input, k.r)
+		}
+
+		b.refresh()
+		return false
+	}
+
+	b.message = ""
+	rows := height - 4
+
+	switch k.code {
+	case keyInterrupt:
+		return true
+	case keyUp:
+		b.cursor--
+	case keyDown:
+		b.cursor++
+	case keyPageUp:
+		b.cursor -= rows
+	case keyPageDown:
+		b.cursor += rows
+	case keyHome:
+		b.cursor = 0
+	case keyEnd:
+		b.cursor = len(b.view) - 1
+	case keyEnter:
+		b.details = !b.details
+	case keyEscape:
+		b.details = false
+	case keyRune:
+		switch k.r {
+		case 'q':
+			return true
+		case 'k':
+			b.cursor--
+		case 'j':
+			b.cursor++
+		case 'c':
+			if b.cursor < len(b.view) {
+				b.selected = b.view[b.cursor]
+				return true
+			}
+		case 'f':
+			if b.cursor < len(b.view) {
+				item := b.view[b.cursor]
+				item.favorite = b.favorites.Toggle(item.node.Address)
+				if err := b.favorites.Save(); err != nil {
+					b.message = err.Error()
+				}
+			}
+		case 'F':
+			b.favoritesOnly = !b.favoritesOnly
+		case 'b':
+			if b.cursor < len(b.view) {
+				b.block(b.view[b.cursor])
+			}
+		case '/':
+			b.ask("Country or city: ", func(value string) { b.location = strings.TrimSpace(value) })
+		case 't':
+			b.cycleType()
+		case 'g':
+			b.ask("Max price per gigabyte, e.g. 500000udvpn (empty to clear): ", func(value string) {
+				b.setMaxPrice(value, &b.maxGigabyte)
+			})
+		case 'h':
+			b.ask("Max price per hour, e.g. 100000udvpn (empty to clear): ", func(value string) {
+				b.setMaxPrice(value, &b.maxHourly)
+			})
+		case 's':
+			b.sortBy = (b.sortBy + 1) % len(browseColumns)
+		case 'r':
+			b.reverse = !b.reverse
+		case 'S':
+			b.ask("Subscription (empty to find one for the node): ", func(value string) {
+				value = strings.TrimSpace(value)
+				if value == "" {
+					b.subscription = 0
+					return
+				}
+
+				id, err := strconv.ParseUint(value, 10, 64)
+				if err != nil {
+					b.message = fmt.Sprintf("Invalid subscription %s", value)
+					return
+				}
+
+				b.subscription = id
+			})
+		}
+	}
+
+	if b.cursor >= len(b.view) {
+		b.cursor = len(b.view) - 1
+	}
+	if b.cursor < 0 {
+		b.cursor = 0
+	}
+
+	b.refresh()
+	return false
+}
+
+// block adds the node to the blocked nodes and removes it from the items.
+func (b *browser) block(item *browseItem) {
+	b.blocked.Add(item.node.Address)
+	if err := b.blocked.Save(); err != nil {
+		b.message = err.Error()
+		return
+	}
+
+	for i := range b.items {
+		if b.items[i] == item {
+			b.items = append(b.items[:i], b.items[i+1:]...)
+			break
+		}
+	}
+
+	b.message = fmt.Sprintf("Blocked %s, unblock with nodes block remove", item.node.Address)
+}
+
+// probe fetches the information of the nodes with a few workers, calling
+// update after each of them.
+func (b *browser) probe(ctx context.Context, apiClient *nodeapi.Client, update func()) {
+	var (
+		jobs  = make(chan *browseItem)
+		group = sync.WaitGroup{}
+	)
+
+	for i := 0; i < browseConcurrency; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+
+			for item := range jobs {
+				info, err := apiClient.FetchInfo(ctx, item.node.Address, item.node.RemoteURL)
+				if ctx.Err() == nil {
+					b.cache.Put(item.node.Address, info, err)
+				}
+
+				b.mutex.Lock()
+				if err == nil || !item.probed {
+					item.node = item.node.WithInfo(info)
+				}
+				item.probed, item.err = true, err
+				b.refresh()
+				b.mutex.Unlock()
+
+				update()
+			}
+		}()
+	}
+
+	b.mutex.Lock()
+	items := append([]*browseItem(nil), b.items...)
+	b.mutex.Unlock()
+
+loop:
+	for _, item := range items {
+		select {
+		case jobs <- item:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+
+	close(jobs)
+	group.Wait()
+}
+
+// run shows the browser until the user quits or selects a node to connect to.
+func (b *browser) run(ctx context.Context, apiClient *nodeapi.Client) error {
+	s, err := openScreen()
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = s.Close() }()
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var (
+		group   sync.WaitGroup
+		keys    = make(chan key)
+		next    = make(chan struct{}, 1)
+		updates = make(chan struct{}, 1)
+		update  = func() {
+			select {
+			case updates <- struct{}{}:
+			default:
+			}
+		}
+	)
+
+	// The probes have to be done and nothing may be left reading the standard
+	// input once the browser exits, e.g. for the passphrase of the keyring.
+	defer func() {
+		cancel()
+		group.Wait()
+	}()
+
+	group.Add(2)
+
+	go func() {
+		defer group.Done()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-next:
+			}
+
+			k, err := s.readKey(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+
+				k = key{code: keyInterrupt}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case keys <- k:
+			}
+		}
+	}()
+
+	go func() {
+		defer group.Done()
+
+		ticker := time.NewTicker(browseProbeInterval)
+		defer ticker.Stop()
+
+		for {
+			b.probe(ctx, apiClient, update)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	next <- struct{}{}
+
+	for {
+		_, height := s.size()
+
+		b.mutex.Lock()
+		lines := b.lines(height)
+		b.mutex.Unlock()
+
+		s.draw(lines)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-updates:
+		case k := <-keys:
+			if b.handle(k, height) {
+				return nil
+			}
+
+			next <- struct{}{}
+		}
+	}
+}
+
+func queryActiveNodes(cmd *cobra.Command, ctx client.Context, planID uint64) ([]nodetypes.Node, error) {
+	var (
+		qsc        = nodetypes.NewQueryServiceClient(ctx)
+		pagination = &query.PageRequest{Limit: 1000}
+	)
+
+	return clienttypes.Paginate(cmd.Context(), pagination, clienttypes.DefaultPaginateOptions(),
+		func(pagination *query.PageRequest) ([]nodetypes.Node, *query.PageResponse, error) {
+			if planID != 0 {
+				result, err := qsc.QueryNodesForPlan(
+					context.Background(),
+					nodetypes.NewQueryNodesForPlanRequest(planID, hubtypes.StatusActive, pagination),
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				return result.Nodes, result.Pagination, nil
+			}
+
+			result, err := qsc.QueryNodes(
+				context.Background(),
+				nodetypes.NewQueryNodesRequest(hubtypes.StatusActive, pagination),
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return result.Nodes, result.Pagination, nil
+		},
+	)
+}
+
+func BrowseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "browse [subscription]",
+		Short: "Browse the active nodes interactively and connect to one of them",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			resume, err := cmd.Flags().GetBool(clienttypes.FlagResume)
+			if err != nil {
+				return err
+			}
+			if resume {
+				return fmt.Errorf("--%s cannot be used with browse, use connect instead", clienttypes.FlagResume)
+			}
+
+			planID, err := cmd.Flags().GetUint64(flagPlanID)
+			if err != nil {
+				return err
+			}
+
+			apiConfig, err := nodeapi.NewConfigFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
+			acceptChanged, err := cmd.Flags().GetBool(clienttypes.FlagTLSAcceptChanged)
+			if err != nil {
+				return err
+			}
+
+			strictTLS := !acceptChanged
+
+			b := &browser{
+				denom:     "udvpn",
+				sortBy:    browseSortBy,
+				favorites: clienttypes.NewFavorites(ctx.HomeDir),
+				blocked:   clienttypes.NewBlocked(ctx.HomeDir),
+				cache:     nodeinfotypes.NewCache(ctx.HomeDir),
+			}
+
+			if len(args) > 0 {
+				b.subscription, err = strconv.ParseUint(args[0], 10, 64)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err = b.favorites.Load(); err != nil {
+				return err
+			}
+			if err = b.blocked.Load(); err != nil {
+				return err
+			}
+			if err = b.cache.Load(); err != nil {
+				return err
+			}
+
+			cacheTTL, err := cmd.Flags().GetDuration(clienttypes.FlagCacheTTL)
+			if err != nil {
+				return err
+			}
+
+			pins := clienttypes.NewPinStore(ctx.HomeDir, nil)
+			if err = pins.Load(); err != nil {
+				return err
+			}
+
+			nodes, err := queryActiveNodes(cmd, ctx, planID)
+			if err != nil {
+				return err
+			}
+
+			// The cached information is shown until the nodes are probed again
+			for i := range nodes {
+				if b.blocked.Has(nodes[i].Address) {
+					continue
+				}
+
+				item := &browseItem{
+					node:     nodeinfotypes.NewNodeFromRaw(&nodes[i]),
+					favorite: b.favorites.Has(nodes[i].Address),
+				}
+
+				if info, ok := b.cache.Get(nodes[i].Address, cacheTTL); ok {
+					item.node, item.probed = item.node.WithInfo(info), true
+				}
+
+				b.items = append(b.items, item)
+			}
+
+			b.refresh()
+
+			apiClient := nodeapi.NewClient(apiConfig).WithTLSConfig(pins.TLSConfigFunc(strictTLS))
+			err = b.run(cmd.Context(), apiClient)
+			apiClient.CloseIdleConnections()
+
+			if err != nil {
+				return err
+			}
+			if err = pins.Save(); err != nil {
+				return err
+			}
+			if err = b.cache.Save(); err != nil {
+				return err
+			}
+			if b.selected == nil {
+				return nil
+			}
+
+			address, err := hubtypes.NodeAddressFromBech32(b.selected.node.Address)
+			if err != nil {
+				return err
+			}
+
+			id := b.subscription
+			if id == 0 {
+				if ctx.FromAddress == nil {
+					return fmt.Errorf("pass the subscription or --%s to find one", flags.FlagFrom)
+				}
+
+				id, err = findSubscription(ctx, subscriptiontypes.NewQueryServiceClient(ctx), address)
+				if err != nil {
+					return err
+				}
+				if id == 0 {
+					return fmt.Errorf("no active subscription of %s can be used for the node %s", ctx.FromAddress, address)
+				}
+			}
+
+			cmd.Printf("Connecting to the node %s (%s) with the subscription %d\n", b.selected.node.Moniker, address, id)
+			return runConnect(cmd, []string{strconv.FormatUint(id, 10), address.String()})
+		},
+	}
+
+	addConnectFlags(cmd)
+
+	cmd.Flags().Uint64(flagPlanID, 0, "browse the nodes of the plan only")
+	cmd.Flags().Duration(clienttypes.FlagCacheTTL, 0, "show the cached information of the nodes probed within this time until probed again")
+
+	return cmd
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/olekukonko/tablewriter"
+	nodetypes "github.com/sentinel-official/hub/x/node/types"
+	"github.com/spf13/cobra"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	nodeapi "github.com/sentinel-official/cli-client/x/node/api"
+	nodeinfotypes "github.com/sentinel-official/cli-client/x/node/types"
+)
+
+const (
+	flagConcurrency = "concurrency"
+)
+
+var (
+	cacheHeader = []string{
+		"Moniker",
+		"Address",
+		"Country",
+		"Type",
+		"Version",
+		"Latency",
+		"Reachable",
+		"Fetched at",
+	}
+)
+
+func CacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "cache",
+		Short:                      "Node information cache subcommands",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		cacheListCmd(),
+		cacheClearCmd(),
+		cacheRefreshCmd(),
+	)
+
+	return cmd
+}
+
+func cacheListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the cached node information and the reachability of the nodes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			cache := nodeinfotypes.NewCache(ctx.HomeDir)
+			if err = cache.Load(); err != nil {
+				return err
+			}
+
+			table := tablewriter.NewWriter(cmd.OutOrStdout())
+			table.SetHeader(cacheHeader)
+
+			for _, address := range cache.Addresses() {
+				item, _ := cache.Entry(address)
+				ok, total := item.Reachability()
+
+				fetchedAt := "never"
+				if !item.FetchedAt.IsZero() {
+					fetchedAt = item.FetchedAt.Format(time.RFC3339)
+				}
+
+				table.Append(
+					[]string{
+						item.Info.Moniker,
+						address,
+						item.Info.Location.Country,
+						clienttypes.ServiceName(item.Info.Type),
+						item.Info.Version,
+						item.AverageLatency().Truncate(time.Millisecond).String(),
+						fmt.Sprintf("%d/%d", ok, total),
+						fetchedAt,
+					},
+				)
+			}
+
+			table.Render()
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func cacheClearCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Remove the cached node information",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			cache := nodeinfotypes.NewCache(ctx.HomeDir)
+			cache.Clear()
+
+			return cache.Save()
+		},
+	}
+
+	return cmd
+}
+
+func cacheRefreshCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Short: "Probe the active nodes and update the cached node information",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			apiConfig, err := nodeapi.NewConfigFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
+			strictTLS, err := cmd.Flags().GetBool(clienttypes.FlagTLSStrict)
+			if err != nil {
+				return err
+			}
+
+			concurrency, err := cmd.Flags().GetInt(flagConcurrency)
+			if err != nil {
+				return err
+			}
+
+			cache := nodeinfotypes.NewCache(ctx.HomeDir)
+			if err = cache.Load(); err != nil {
+				return err
+			}
+
+			pins := clienttypes.NewPinStore(ctx.HomeDir, cmd.ErrOrStderr())
+			if err = pins.Load(); err != nil {
+				return err
+			}
+
+			nodes, err := queryActiveNodes(cmd, ctx, 0)
+			if err != nil {
+				return err
+			}
+
+			var (
+				apiClient = nodeapi.NewClient(apiConfig).WithTLSConfig(pins.TLSConfigFunc(strictTLS))
+				reachable int
+			)
+
+			defer apiClient.CloseIdleConnections()
+
+			apiClient.FetchInfos(cmd.Context(), nodes, concurrency,
+				func(node *nodetypes.Node, info nodeinfotypes.Info, err error) {
+					cache.Put(node.Address, info, err)
+					if err == nil {
+						reachable++
+					}
+				},
+			)
+
+			if err = pins.Save(); err != nil {
+				return err
+			}
+			if err = cache.Save(); err != nil {
+				return err
+			}
+
+			cmd.Printf("Probed %d nodes, %d reachable\n", len(nodes), reachable)
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	nodeapi.AddFlagsToCmd(cmd)
+
+	cmd.Flags().Int(flagConcurrency, 16, "number of nodes probed at the same time")
+	cmd.Flags().Bool(clienttypes.FlagTLSStrict, false, "fail if the certificate of a node does not match the pinned one")
+
+	return cmd
+}
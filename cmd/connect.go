@@ -1,17 +1,9 @@
 package cmd
 
 import (
-	"bytes"
 	"context"
-	"crypto/tls"
-	"encoding/base64"
-	"encoding/binary"
-	"encoding/json"
 	"fmt"
 	"net"
-	"net/http"
-	"net/url"
-	"path/filepath"
 	"strconv"
 	"time"
 
@@ -20,50 +12,69 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/query"
-	"github.com/go-kit/kit/transport/http/jsonrpc"
-	"github.com/hashicorp/go-uuid"
 	"github.com/pkg/errors"
 	hubtypes "github.com/sentinel-official/hub/types"
 	nodetypes "github.com/sentinel-official/hub/x/node/types"
 	sessiontypes "github.com/sentinel-official/hub/x/session/types"
 	"github.com/spf13/cobra"
 
-	"github.com/sentinel-official/cli-client/services/v2ray"
-	v2raytypes "github.com/sentinel-official/cli-client/services/v2ray/types"
-	"github.com/sentinel-official/cli-client/services/wireguard"
-	wireguardtypes "github.com/sentinel-official/cli-client/services/wireguard/types"
 	clienttypes "github.com/sentinel-official/cli-client/types"
-	netutil "github.com/sentinel-official/cli-client/utils/net"
+	nodeapi "github.com/sentinel-official/cli-client/x/node/api"
+	nodeinfotypes "github.com/sentinel-official/cli-client/x/node/types"
 )
 
-func fetchNodeInfo(remoteURL string, timeout time.Duration) (map[string]interface{}, error) {
-	endpoint, err := url.JoinPath(remoteURL, "status")
+const (
+	addSessionRetries   = 5
+	sessionPollInterval = 2 * time.Second
+	sessionWaitTimeout  = 1 * time.Minute
+)
+
+func exchangeKey(
+	c context.Context, ctx client.Context, apiClient *nodeapi.Client, node *nodetypes.Node, id uint64, key string,
+) ([]byte, error) {
+	signature, _, err := ctx.Keyring.Sign(ctx.From, sdk.Uint64ToBigEndian(id))
 	if err != nil {
 		return nil, err
 	}
 
-	httpClient := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-		Timeout: timeout,
-	}
+	return apiClient.AddSession(c, node.Address, node.RemoteURL, ctx.FromAddress, id, key, signature)
+}
 
-	resp, err := httpClient.Get(endpoint)
-	if err != nil {
-		return nil, err
+// exchangeKeyForSession exchanges the key, retrying with a backoff while the
+// node does not know the session, since the node may lag behind the chain.
+func exchangeKeyForSession(
+	cmd *cobra.Command, ctx client.Context, apiClient *nodeapi.Client, node *nodetypes.Node, id uint64, key string,
+) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		result, err := exchangeKey(cmd.Context(), ctx, apiClient, node, id, key)
+
+		var rpcErr *clienttypes.Error
+		if err == nil || attempt >= addSessionRetries || !errors.As(err, &rpcErr) || !sessionNotFound(rpcErr) {
+			return result, err
+		}
+
+		delay := sessionPollInterval << attempt
+		cmd.PrintErrf("Node does not know the session %d yet; retrying in %s\n", id, delay)
+
+		select {
+		case <-cmd.Context().Done():
+			return nil, err
+		case <-time.After(delay):
+		}
 	}
+}
 
-	defer resp.Body.Close()
+func newServiceFromStatus(status *clienttypes.Status) (clienttypes.Service, error) {
+	if status.Type == 0 {
+		return nil, nil
+	}
 
-	var body clienttypes.Response
-	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+	definition, err := clienttypes.GetService(status.Type)
+	if err != nil {
 		return nil, err
 	}
 
-	return body.Result.(map[string]interface{}), nil
+	return definition.DecodeConfig(status.Info)
 }
 
 func queryNode(qsc nodetypes.QueryServiceClient, address hubtypes.NodeAddress) (*nodetypes.Node, error) {
@@ -109,364 +120,537 @@ func queryActiveSession(qsc sessiontypes.QueryServiceClient, address sdk.AccAddr
 	return nil, nil
 }
 
-func ConnectCmd() *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "connect [subscription] [address]",
-		Short: "Connect to a node",
-		Args:  cobra.ExactArgs(2),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx, err := client.GetClientTxContext(cmd)
-			if err != nil {
-				return err
-			}
+// queryReusableSession returns an active session for the same subscription and node.
+func queryReusableSession(
+	qsc sessiontypes.QueryServiceClient, status *clienttypes.Status,
+	from sdk.AccAddress, id uint64, address hubtypes.NodeAddress,
+) (*sessiontypes.Session, error) {
+	var session *sessiontypes.Session
+	if status.Session != 0 {
+		result, err := qsc.QuerySession(
+			context.Background(),
+			sessiontypes.NewQuerySessionRequest(status.Session),
+		)
+		if err != nil {
+			// Sessions are pruned from the chain once they are inactive
+			return nil, nil
+		}
 
-			id, err := strconv.ParseUint(args[0], 10, 64)
-			if err != nil {
-				return err
-			}
+		session = &result.Session
+	} else {
+		result, err := queryActiveSession(qsc, from)
+		if err != nil {
+			return nil, err
+		}
 
-			address, err := hubtypes.NodeAddressFromBech32(args[1])
-			if err != nil {
-				return err
-			}
+		session = result
+	}
 
-			timeout, err := cmd.Flags().GetDuration(clienttypes.FlagTimeout)
-			if err != nil {
-				return err
-			}
+	if session == nil || session.Status != hubtypes.StatusActive {
+		return nil, nil
+	}
+	if session.Address != from.String() || session.SubscriptionID != id || session.NodeAddress != address.String() {
+		return nil, nil
+	}
+
+	return session, nil
+}
 
-			ss, err := cmd.Flags().GetStringArray(clienttypes.FlagResolver)
+// waitForSession polls for the session started for the subscription and node,
+// which is not available until the transaction is included in a block.
+func waitForSession(
+	qsc sessiontypes.QueryServiceClient, from sdk.AccAddress, id uint64, address hubtypes.NodeAddress,
+	previous uint64, timeout time.Duration,
+) (*sessiontypes.Session, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		session, err := queryActiveSession(qsc, from)
+		if err == nil && session != nil && session.ID != previous &&
+			session.SubscriptionID == id && session.NodeAddress == address.String() {
+			return session, nil
+		}
+		if time.Now().After(deadline) {
 			if err != nil {
-				return err
+				return nil, err
 			}
 
-			var resolvers []net.IP
-			for _, s := range ss {
-				ip := net.ParseIP(s)
-				if ip == nil {
-					return fmt.Errorf("invalid resolver ip %s", s)
-				}
+			return nil, errors.New("no active session found")
+		}
 
-				resolvers = append(resolvers, ip)
-			}
+		time.Sleep(sessionPollInterval)
+	}
+}
 
-			v2RayProxyPort, err := cmd.Flags().GetUint16(clienttypes.FlagV2RayProxyPort)
-			if err != nil {
-				return err
-			}
+// startSession starts a session, ending the active one unless a connection uses it.
+func startSession(
+	cmd *cobra.Command, ctx client.Context, qsc sessiontypes.QueryServiceClient,
+	name string, id uint64, address hubtypes.NodeAddress,
+) (*sessiontypes.Session, error) {
+	var (
+		messages []sdk.Msg
+		previous uint64
+	)
 
-			var (
-				status         = clienttypes.NewStatus()
-				statusFilePath = filepath.Join(ctx.HomeDir, "status.json")
-			)
+	session, err := queryActiveSession(qsc, ctx.FromAddress)
+	if err != nil {
+		return nil, err
+	}
+	if session != nil {
+		previous = session.ID
+	}
 
-			if err = status.LoadFromPath(statusFilePath); err != nil {
-				return err
-			}
+	ownedSessions, err := sessionsOfOtherConnections(ctx.HomeDir, name)
+	if err != nil {
+		return nil, err
+	}
 
-			var service clienttypes.Service
-			if status.Type == 1 {
-				var cfg wireguardtypes.Config
-				if err = json.Unmarshal(status.Info, &cfg); err != nil {
-					return err
-				}
+	// Add a MsgEndRequest if session is active and not used by another connection
+	if session != nil && !ownedSessions[session.ID] {
+		messages = append(
+			messages,
+			sessiontypes.NewMsgEndRequest(
+				ctx.FromAddress,
+				session.ID,
+				0,
+			),
+		)
+	}
 
-				service = wireguard.NewWireGuard(&cfg)
-			} else if status.Type == 2 {
-				var cfg v2raytypes.Config
-				if err = json.Unmarshal(status.Info, &cfg); err != nil {
-					return err
-				}
+	messages = append(
+		messages,
+		sessiontypes.NewMsgStartRequest(
+			ctx.FromAddress,
+			id,
+			address,
+		),
+	)
 
-				service = v2ray.NewV2Ray(&cfg)
-			}
+	if err = tx.GenerateOrBroadcastTxCLI(ctx, cmd.Flags(), messages...); err != nil {
+		// The transaction may have been included even though the broadcast
+		// failed, e.g. when the RPC endpoint timed out waiting for it
+		session, qErr := waitForSession(qsc, ctx.FromAddress, id, address, previous, sessionPollInterval)
+		if qErr != nil {
+			return nil, err
+		}
 
-			if service != nil && service.IsUp() {
-				if err = service.PreDown(); err != nil {
-					return err
-				}
-				if err = service.Down(); err != nil {
-					return err
-				}
-				if err = service.PostDown(); err != nil {
-					return err
-				}
-			}
+		cmd.PrintErrf("Broadcast failed: %s; found the started session %d\n", err, session.ID)
+		return session, nil
+	}
 
-			nodeQueryClient := nodetypes.NewQueryServiceClient(ctx)
+	return waitForSession(qsc, ctx.FromAddress, id, address, previous, sessionWaitTimeout)
+}
 
-			node, err := queryNode(nodeQueryClient, address)
-			if err != nil {
-				return err
-			}
+// runConnect connects to the node with the args of the connect command, the
+// flags of which are expected on cmd.
+func runConnect(cmd *cobra.Command, args []string) error {
+	ctx, err := client.GetClientTxContext(cmd)
+	if err != nil {
+		return err
+	}
 
-			nodeInfo, err := fetchNodeInfo(node.RemoteURL, timeout)
-			if err != nil {
-				return err
-			}
+	resume, err := cmd.Flags().GetBool(clienttypes.FlagResume)
+	if err != nil {
+		return err
+	}
 
-			var (
-				nodeType           = uint64(nodeInfo["type"].(float64))
-				messages           []sdk.Msg
-				sessionQueryClient = sessiontypes.NewQueryServiceClient(ctx)
-			)
+	apiConfig, err := nodeapi.NewConfigFromCmd(cmd)
+	if err != nil {
+		return err
+	}
 
-			session, err := queryActiveSession(sessionQueryClient, ctx.FromAddress)
-			if err != nil {
-				return err
-			}
+	ss, err := cmd.Flags().GetStringArray(clienttypes.FlagResolver)
+	if err != nil {
+		return err
+	}
 
-			// Add a MsgEndRequest if session is active
-			if session != nil {
-				messages = append(
-					messages,
-					sessiontypes.NewMsgEndRequest(
-						ctx.FromAddress,
-						session.ID,
-						0,
-					),
-				)
-			}
+	var resolvers []net.IP
+	for _, s := range ss {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return fmt.Errorf("invalid resolver ip %s", s)
+		}
 
-			messages = append(
-				messages,
-				sessiontypes.NewMsgStartRequest(
-					ctx.FromAddress,
-					id,
-					address,
-				),
-			)
+		resolvers = append(resolvers, ip)
+	}
 
-			if err = tx.GenerateOrBroadcastTxCLI(ctx, cmd.Flags(), messages...); err != nil {
-				return err
-			}
+	v2RayProxyPort, err := cmd.Flags().GetUint16(clienttypes.FlagV2RayProxyPort)
+	if err != nil {
+		return err
+	}
 
-			session, err = queryActiveSession(sessionQueryClient, ctx.FromAddress)
-			if err != nil {
-				return err
-			}
+	name, err := cmd.Flags().GetString(clienttypes.FlagName)
+	if err != nil {
+		return err
+	}
+	if err = validateConnectionName(name); err != nil {
+		return err
+	}
 
-			if session == nil {
-				return errors.New("no active session found")
-			}
+	forceReset, err := cmd.Flags().GetBool(clienttypes.FlagForceReset)
+	if err != nil {
+		return err
+	}
 
-			var (
-				key          string
-				wgPrivateKey *wireguardtypes.Key
-				uid          []byte
-			)
+	reuseSession, err := cmd.Flags().GetBool(clienttypes.FlagReuseSession)
+	if err != nil {
+		return err
+	}
 
-			if nodeType == 1 {
-				wgPrivateKey, err = wireguardtypes.NewPrivateKey()
-				if err != nil {
-					return err
-				}
+	// The key is sent to the node, so a changed certificate fails the connect
+	// unless it is accepted explicitly
+	acceptChanged, err := cmd.Flags().GetBool(clienttypes.FlagTLSAcceptChanged)
+	if err != nil {
+		return err
+	}
 
-				key = wgPrivateKey.Public().String()
-			} else if nodeType == 2 {
-				uid, err = uuid.GenerateRandomBytes(16)
-				if err != nil {
-					return err
-				}
+	strictTLS := !acceptChanged
 
-				key = base64.StdEncoding.EncodeToString(append([]byte{0x01}, uid...))
-			} else {
-				return fmt.Errorf("invalid node type %d", nodeType)
-			}
+	dryRun, err := cmd.Flags().GetBool(clienttypes.FlagDryRun)
+	if err != nil {
+		return err
+	}
 
-			signature, _, err := ctx.Keyring.Sign(ctx.From, sdk.Uint64ToBigEndian(session.ID))
-			if err != nil {
-				return err
-			}
+	skipChecks, err := cmd.Flags().GetBool(clienttypes.FlagSkipChecks)
+	if err != nil {
+		return err
+	}
 
-			req, err := json.Marshal(
-				map[string]interface{}{
-					"key":       key,
-					"signature": signature,
-				},
-			)
-			if err != nil {
-				return err
-			}
+	limits, err := readLimits(cmd)
+	if err != nil {
+		return err
+	}
 
-			endpoint, err := url.JoinPath(node.RemoteURL, fmt.Sprintf("/accounts/%s/sessions/%d", ctx.FromAddress, session.ID))
-			if err != nil {
-				return err
-			}
+	wait, err := cmd.Flags().GetBool(clienttypes.FlagWait)
+	if err != nil {
+		return err
+	}
 
-			var (
-				body       clienttypes.Response
-				httpClient = http.Client{
-					Transport: &http.Transport{
-						TLSClientConfig: &tls.Config{
-							InsecureSkipVerify: true,
-						},
-					},
-					Timeout: timeout,
-				}
-			)
+	endSessionOnExit, err := cmd.Flags().GetBool(clienttypes.FlagEndSession)
+	if err != nil {
+		return err
+	}
 
-			resp, err := httpClient.Post(endpoint, jsonrpc.ContentType, bytes.NewBuffer(req))
-			if err != nil {
-				return err
-			}
+	serviceOptions := &clienttypes.ServiceOptions{
+		Name:      name,
+		Resolvers: resolvers,
+		ProxyPort: v2RayProxyPort,
+	}
 
-			defer resp.Body.Close()
+	if dryRun {
+		if resume {
+			return fmt.Errorf("--%s cannot be used with --%s", clienttypes.FlagDryRun, clienttypes.FlagResume)
+		}
 
-			if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
-				return err
-			}
-			if body.Error != nil {
-				return errors.New(body.Error.Message)
-			}
+		id, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return err
+		}
 
-			result, err := base64.StdEncoding.DecodeString(body.Result.(string))
-			if err != nil {
-				return err
-			}
+		address, err := hubtypes.NodeAddressFromBech32(args[1])
+		if err != nil {
+			return err
+		}
 
-			if nodeType == 1 {
-				if len(result) != 58 {
-					return fmt.Errorf("incorrect result size %d", len(result))
-				}
+		pins := clienttypes.NewPinStore(ctx.HomeDir, cmd.ErrOrStderr())
+		if err = pins.Load(); err != nil {
+			return err
+		}
 
-				var (
-					ipv4Address         = net.IP(result[0:4])
-					ipv6Address         = net.IP(result[4:20])
-					endpointHost        = net.IP(result[20:24])
-					endpointPort        = binary.BigEndian.Uint16(result[24:26])
-					endpointWGPublicKey = wireguardtypes.NewKey(result[26:58])
-				)
+		apiClient := nodeapi.NewClient(apiConfig).WithTLSConfig(pins.TLSConfigFunc(strictTLS))
 
-				listenPort, err := netutil.GetFreeUDPPort()
-				if err != nil {
-					return err
-				}
+		defer apiClient.CloseIdleConnections()
 
-				cfg := &wireguardtypes.Config{
-					Name: wireguardtypes.DefaultInterface,
-					Interface: wireguardtypes.Interface{
-						Addresses: []wireguardtypes.IPNet{
-							{IP: ipv4Address, Net: 32},
-							{IP: ipv6Address, Net: 128},
-						},
-						ListenPort: listenPort,
-						PrivateKey: *wgPrivateKey,
-						DNS: append(
-							[]net.IP{net.ParseIP("10.8.0.1")},
-							resolvers...,
-						),
-					},
-					Peers: []wireguardtypes.Peer{
-						{
-							PublicKey: *endpointWGPublicKey,
-							AllowedIPs: []wireguardtypes.IPNet{
-								{IP: net.ParseIP("0.0.0.0")},
-								{IP: net.ParseIP("::")},
-							},
-							Endpoint: wireguardtypes.Endpoint{
-								Host: endpointHost.String(),
-								Port: endpointPort,
-							},
-							PersistentKeepalive: 15,
-						},
-					},
-				}
+		plan, err := planConnect(cmd, ctx, apiClient, name, id, address, reuseSession, serviceOptions)
+		plan.render(cmd)
 
-				service = wireguard.NewWireGuard(cfg)
-			} else if nodeType == 2 {
-				if len(result) != 7 {
-					return fmt.Errorf("incorrect result size %d", len(result))
-				}
+		return err
+	}
 
-				var (
-					vMessAddress   = net.IP(result[0:4])
-					vMessPort      = binary.BigEndian.Uint16(result[4:6])
-					vMessTransport = func() string {
-						switch result[6] {
-						case 0x01:
-							return "tcp"
-						case 0x02:
-							return "mkcp"
-						case 0x03:
-							return "websocket"
-						case 0x04:
-							return "http"
-						case 0x05:
-							return "domainsocket"
-						case 0x06:
-							return "quic"
-						case 0x07:
-							return "gun"
-						case 0x08:
-							return "grpc"
-						default:
-							return ""
-						}
-					}()
-				)
+	lock, err := acquireLock(ctx.HomeDir)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = lock.Release() }()
+
+	hs, err := loadHandshake(ctx.HomeDir, name)
+	if err != nil {
+		return err
+	}
+
+	if resume {
+		if hs == nil {
+			return fmt.Errorf("no interrupted connect found for the connection %s", name)
+		}
+	} else {
+		if hs != nil {
+			cmd.PrintErrf("Discarding the interrupted connect to node %s with session %d\n", hs.Node, hs.Session)
+		}
+
+		id, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return err
+		}
+
+		address, err := hubtypes.NodeAddressFromBech32(args[1])
+		if err != nil {
+			return err
+		}
+
+		hs = &handshake{
+			Step: stepStartSession,
+			ID:   id,
+			Node: address.String(),
+		}
+	}
+
+	var (
+		id = hs.ID
+	)
+
+	address, err := hubtypes.NodeAddressFromBech32(hs.Node)
+	if err != nil {
+		return err
+	}
+
+	status, err := loadStatusOrReset(ctx.HomeDir, name, forceReset)
+	if err != nil {
+		return err
+	}
+
+	service, err := newServiceFromStatus(status)
+	if err != nil {
+		return err
+	}
+
+	if service != nil && service.IsUp() {
+		if err = service.Down(cmd.Context()); err != nil {
+			return err
+		}
+	}
+
+	nodeQueryClient := nodetypes.NewQueryServiceClient(ctx)
+
+	node, err := queryNode(nodeQueryClient, address)
+	if err != nil {
+		return err
+	}
+
+	pins := clienttypes.NewPinStore(ctx.HomeDir, cmd.ErrOrStderr())
+	if err = pins.Load(); err != nil {
+		return err
+	}
+
+	apiClient := nodeapi.NewClient(apiConfig).WithTLSConfig(pins.TLSConfigFunc(strictTLS))
+
+	defer apiClient.CloseIdleConnections()
 
-				uidStr, err := uuid.FormatUUID(uid)
+	nodeInfo, err := apiClient.FetchInfo(cmd.Context(), node.Address, node.RemoteURL)
+	if err != nil {
+		return err
+	}
+	if err = pins.Save(); err != nil {
+		return err
+	}
+
+	mismatches := nodeinfotypes.Verify(node, nodeInfo)
+	for _, item := range mismatches {
+		cmd.PrintErrf("WARNING: node information mismatch, %s\n", item)
+	}
+	if len(mismatches.Fatal()) > 0 {
+		return fmt.Errorf("refusing to connect, node %s failed the identity verification", address)
+	}
+
+	var (
+		nodeType           = nodeInfo.Type
+		sessionQueryClient = sessiontypes.NewQueryServiceClient(ctx)
+	)
+
+	definition, err := clienttypes.GetService(nodeType)
+	if err != nil {
+		return err
+	}
+
+	if !skipChecks {
+		checks := runPreflight(ctx, []clienttypes.ServiceDefinition{definition}, id, address, serviceOptions)
+		if failed := checks.Failed(); len(failed) > 0 {
+			renderChecks(cmd, failed)
+			return fmt.Errorf("pre-flight checks failed; run doctor for the details or pass --%s", clienttypes.FlagSkipChecks)
+		}
+	}
+
+	if hs.Key == "" {
+		hs.Key, hs.Secret, err = definition.GenerateKey()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = saveHandshake(ctx.HomeDir, name, hs); err != nil {
+		return err
+	}
+
+	for hs.Step != stepDone {
+		switch hs.Step {
+		case stepStartSession:
+			var session *sessiontypes.Session
+			if reuseSession {
+				session, err = queryReusableSession(sessionQueryClient, status, ctx.FromAddress, id, address)
 				if err != nil {
 					return err
 				}
-
-				apiPort, err := netutil.GetFreeTCPPort()
+			}
+			if session == nil && resume {
+				// The session may have been started before the connect was interrupted
+				session, err = queryReusableSession(sessionQueryClient, clienttypes.NewStatus(), ctx.FromAddress, id, address)
 				if err != nil {
 					return err
 				}
+			}
 
-				cfg := &v2raytypes.Config{
-					API: &v2raytypes.APIConfig{
-						Port: apiPort,
-					},
-					Proxy: &v2raytypes.ProxyConfig{
-						Port: v2RayProxyPort,
-					},
-					VMess: &v2raytypes.VMessConfig{
-						Address:   vMessAddress.String(),
-						ID:        uidStr,
-						Port:      vMessPort,
-						Transport: vMessTransport,
-					},
+			hs.Reused = session != nil
+			if session == nil {
+				session, err = startSession(cmd, ctx, sessionQueryClient, name, id, address)
+				if err != nil {
+					return resumableError(name, err)
 				}
-
-				service = v2ray.NewV2Ray(cfg)
-			} else {
-				return fmt.Errorf("invalid node type %d", nodeType)
 			}
 
-			if err = service.PreUp(); err != nil {
-				return err
+			hs.Session = session.ID
+			hs.Step = stepExchangeKey
+		case stepExchangeKey:
+			hs.Result, err = exchangeKeyForSession(cmd, ctx, apiClient, node, hs.Session, hs.Key)
+			if err != nil {
+				var rpcErr *clienttypes.Error
+				if !errors.As(err, &rpcErr) {
+					return resumableError(name, err)
+				}
+				if keyConflict(rpcErr) {
+					// Resending the same key cannot succeed, give up the session after a few tries
+					hs.Conflicts++
+					if hs.Conflicts < maxKeyConflicts {
+						cause := resumableError(name, err)
+						if err = saveHandshake(ctx.HomeDir, name, hs); err != nil {
+							return err
+						}
+
+						return cause
+					}
+				} else if !definitiveRejection(rpcErr) {
+					return resumableError(name, err)
+				}
+				if !hs.Reused {
+					return rollbackHandshake(cmd, ctx, name, hs, err)
+				}
+
+				cmd.PrintErrf("Node rejected the session %d: %s; starting a new session\n", hs.Session, rpcErr.Message)
+				reuseSession, resume = false, false
+				hs.Step, hs.Conflicts = stepStartSession, 0
+				break
 			}
-			if err = service.Up(); err != nil {
-				return err
+
+			hs.Step = stepUp
+		case stepUp:
+			service, err = definition.ParseResult(hs.Result, hs.Secret, serviceOptions)
+			if err != nil {
+				return rollbackHandshake(cmd, ctx, name, hs, err)
 			}
-			if err = service.PostUp(); err != nil {
-				return err
+
+			if err = service.Up(cmd.Context()); err != nil {
+				_ = service.Down(context.Background())
+				return resumableError(name, err)
 			}
 
 			status = clienttypes.NewStatus().
+				WithName(name).
 				WithFrom(ctx.GetFromName()).
 				WithID(id).
+				WithSession(hs.Session).
 				WithInfo(service.Info()).
 				WithTo(address.String()).
 				WithType(nodeType)
 
-			if err = status.SaveToPath(statusFilePath); err != nil {
+			if err = saveStatus(ctx.HomeDir, name, status); err != nil {
+				// Do not leave a tunnel behind which cannot be found by disconnect
+				_ = service.Down(context.Background())
+				return resumableError(name, err)
+			}
+
+			hs.Step = stepDone
+		default:
+			return fmt.Errorf("invalid connect step %s", hs.Step)
+		}
+
+		if hs.Step != stepDone {
+			if err = saveHandshake(ctx.HomeDir, name, hs); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = removeHandshake(ctx.HomeDir, name); err != nil {
+		return err
+	}
+	if !wait && limits.isZero() {
+		return nil
+	}
+
+	// Other commands, e.g. disconnect, must not wait for the monitoring
+	_ = lock.Release()
+
+	m := &monitor{
+		name:       name,
+		id:         id,
+		session:    hs.Session,
+		moniker:    nodeInfo.Moniker,
+		limits:     limits,
+		live:       wait,
+		endSession: endSessionOnExit,
+	}
+
+	return m.run(cmd, ctx)
+}
+
+func ConnectCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "connect [subscription] [address]",
+		Short: "Connect to a node",
+		Args: func(cmd *cobra.Command, args []string) error {
+			resume, err := cmd.Flags().GetBool(clienttypes.FlagResume)
+			if err != nil {
 				return err
 			}
+			if resume {
+				return cobra.NoArgs(cmd, args)
+			}
 
-			return nil
+			return cobra.ExactArgs(2)(cmd, args)
 		},
+		RunE: runConnect,
 	}
 
+	addConnectFlags(cmd)
+
+	return cmd
+}
+
+func addConnectFlags(cmd *cobra.Command) {
 	flags.AddTxFlagsToCmd(cmd)
+	nodeapi.AddFlagsToCmd(cmd)
 
 	cmd.Flags().String(flags.FlagChainID, "sentinelhub-2", "the network chain identity")
+	cmd.Flags().String(clienttypes.FlagMaxBytes, "", "disconnect and end the session after transferring this much data (e.g. 5GB)")
+	cmd.Flags().Duration(clienttypes.FlagMaxDuration, 0, "disconnect and end the session after this much time (e.g. 2h)")
+	cmd.Flags().String(clienttypes.FlagName, defaultConnectionName, "name of the connection")
+	cmd.Flags().Bool(clienttypes.FlagDryRun, false, "show what the connect would do without signing or changing anything")
+	cmd.Flags().Bool(clienttypes.FlagEndSession, false, "end the session as well when interrupted in the foreground")
+	cmd.Flags().Bool(clienttypes.FlagForceReset, false, "discard a corrupt status of the connection")
+	cmd.Flags().Bool(clienttypes.FlagResume, false, "continue the interrupted connect of the connection")
+	cmd.Flags().Bool(clienttypes.FlagReuseSession, false, "reuse the active session for the same subscription and node")
+	cmd.Flags().Bool(clienttypes.FlagSkipChecks, false, "do not run the pre-flight checks")
 	cmd.Flags().StringArray(clienttypes.FlagResolver, []string{"1.0.0.1", "1.1.1.1"}, "provide additional DNS servers")
-	cmd.Flags().Duration(clienttypes.FlagTimeout, 15*time.Second, "time limit for requests made by the HTTP client")
-	cmd.Flags().Uint16(clienttypes.FlagV2RayProxyPort, 1080, "port number fot the V2Ray SOCKS proxy")
-
-	return cmd
+	cmd.Flags().Bool(clienttypes.FlagTLSAcceptChanged, false, "connect even if the certificate of the node does not match the pinned one")
+	cmd.Flags().Uint16(clienttypes.FlagV2RayProxyPort, 1080, "port number for the V2Ray SOCKS proxy")
+	cmd.Flags().Bool(clienttypes.FlagWait, false, "keep running in the foreground showing the throughput, disconnect when interrupted")
+	cmd.Flags().IntSlice(clienttypes.FlagWarnThresholds, []int{25, 10}, "warn when the allocation left falls below these percentages, while enforcing the limits")
 }
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	osutil "github.com/sentinel-official/cli-client/utils/os"
+)
+
+const (
+	defaultConnectionName = "default"
+)
+
+var (
+	connectionNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,32}$`)
+)
+
+func validateConnectionName(name string) error {
+	if !connectionNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid connection name %s", name)
+	}
+
+	return nil
+}
+
+func acquireLock(home string) (*osutil.Lock, error) {
+	return osutil.AcquireLock(filepath.Join(home, "sentinelcli.lock"))
+}
+
+func connectionsDir(home string) string {
+	return filepath.Join(home, "connections")
+}
+
+func statusFilePath(home, name string) string {
+	return filepath.Join(connectionsDir(home), name+".json")
+}
+
+// migrateLegacyStatus moves the status file written by older versions, which
+// supported a single connection only, into place for the default connection.
+func migrateLegacyStatus(home string) error {
+	legacyFilePath := filepath.Join(home, "status.json")
+	if _, err := os.Stat(legacyFilePath); err != nil {
+		return nil
+	}
+
+	filePath := statusFilePath(home, defaultConnectionName)
+	if _, err := os.Stat(filePath); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(connectionsDir(home), 0700); err != nil {
+		return err
+	}
+
+	return os.Rename(legacyFilePath, filePath)
+}
+
+func listConnections(home string) ([]string, error) {
+	if err := migrateLegacyStatus(home); err != nil {
+		return nil, err
+	}
+
+	return readConnections(home)
+}
+
+// readConnections lists the connections like listConnections, but counts a
+// legacy status file as the default connection instead of moving it.
+func readConnections(home string) ([]string, error) {
+	entries, err := os.ReadDir(connectionsDir(home))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var (
+		names      []string
+		hasDefault bool
+	)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		if name == defaultConnectionName {
+			hasDefault = true
+		}
+
+		names = append(names, name)
+	}
+
+	if !hasDefault {
+		if _, err = os.Stat(filepath.Join(home, "status.json")); err == nil {
+			names = append(names, defaultConnectionName)
+		}
+	}
+
+	return names, nil
+}
+
+func loadStatus(home, name string) (*clienttypes.Status, error) {
+	if name == defaultConnectionName {
+		if err := migrateLegacyStatus(home); err != nil {
+			return nil, err
+		}
+	}
+
+	status := clienttypes.NewStatus()
+	if err := status.LoadFromPath(statusFilePath(home, name)); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// readStatus loads the status of a connection like loadStatus, but reads a
+// legacy status file of the default connection in place instead of moving it.
+func readStatus(home, name string) (*clienttypes.Status, error) {
+	filePath := statusFilePath(home, name)
+	if name == defaultConnectionName {
+		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			filePath = filepath.Join(home, "status.json")
+		}
+	}
+
+	status := clienttypes.NewStatus()
+	if err := status.LoadFromPath(filePath); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// loadStatusOrReset loads a status, discarding a corrupt or unsupported one with reset.
+func loadStatusOrReset(home, name string, reset bool) (*clienttypes.Status, error) {
+	status, err := loadStatus(home, name)
+	if err == nil {
+		return status, nil
+	}
+	if !errors.Is(err, clienttypes.ErrorCorruptStatus) && !errors.Is(err, clienttypes.ErrorUnsupportedStatus) {
+		return nil, err
+	}
+	if !reset {
+		return nil, fmt.Errorf("%w; pass --%s to discard it", err, clienttypes.FlagForceReset)
+	}
+
+	if err = removeStatus(home, name); err != nil {
+		return nil, err
+	}
+
+	return clienttypes.NewStatus(), nil
+}
+
+func saveStatus(home, name string, status *clienttypes.Status) error {
+	if err := os.MkdirAll(connectionsDir(home), 0700); err != nil {
+		return err
+	}
+
+	return status.SaveToPath(statusFilePath(home, name))
+}
+
+func removeStatus(home, name string) error {
+	err := os.Remove(statusFilePath(home, name))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// fullTunnelConnection returns the name of a connection other than the given
+// one which is up and routes all the traffic, or an empty string.
+func fullTunnelConnection(home, name string) (string, error) {
+	names, err := readConnections(home)
+	if err != nil {
+		return "", err
+	}
+
+	for _, item := range names {
+		if item == name {
+			continue
+		}
+
+		status, err := readStatus(home, item)
+		if err != nil {
+			return "", err
+		}
+		if status.Type == 0 {
+			continue
+		}
+
+		definition, err := clienttypes.GetService(status.Type)
+		if err != nil {
+			return "", err
+		}
+		if !definition.FullTunnel {
+			continue
+		}
+
+		service, err := definition.DecodeConfig(status.Info)
+		if err != nil {
+			return "", err
+		}
+		if service.IsUp() {
+			return item, nil
+		}
+	}
+
+	return "", nil
+}
+
+// sessionsOfOtherConnections returns the IDs of the sessions owned by all
+// the connections except the given one.
+func sessionsOfOtherConnections(home, name string) (map[uint64]bool, error) {
+	names, err := readConnections(home)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(map[uint64]bool)
+	for _, item := range names {
+		if item == name {
+			continue
+		}
+
+		status, err := readStatus(home, item)
+		if err != nil {
+			return nil, err
+		}
+		if status.Session != 0 {
+			items[status.Session] = true
+		}
+	}
+
+	return items, nil
+}
+
+// proxyPortsOfConnections returns the ports of the local proxies of the
+// connections which are up, by the names of the connections.
+func proxyPortsOfConnections(home string) (map[uint16]string, error) {
+	names, err := readConnections(home)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(map[uint16]string)
+	for _, item := range names {
+		status, err := readStatus(home, item)
+		if err != nil {
+			return nil, err
+		}
+
+		service, err := newServiceFromStatus(status)
+		if err != nil {
+			return nil, err
+		}
+
+		proxied, ok := service.(clienttypes.Proxied)
+		if !ok || !service.IsUp() {
+			continue
+		}
+
+		proxyURL := proxied.ProxyURL()
+		if proxyURL == nil {
+			continue
+		}
+
+		port, err := strconv.ParseUint(proxyURL.Port(), 10, 16)
+		if err != nil {
+			return nil, err
+		}
+
+		items[uint16(port)] = item
+	}
+
+	return items, nil
+}
@@ -1,20 +1,37 @@
 package cmd
 
 import (
-	"encoding/json"
-	"os"
-	"path/filepath"
+	"context"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/spf13/cobra"
 
-	"github.com/sentinel-official/cli-client/services/v2ray"
-	v2raytypes "github.com/sentinel-official/cli-client/services/v2ray/types"
-	"github.com/sentinel-official/cli-client/services/wireguard"
-	wireguardtypes "github.com/sentinel-official/cli-client/services/wireguard/types"
 	clienttypes "github.com/sentinel-official/cli-client/types"
 )
 
+func disconnect(ctx context.Context, home, name string, reset bool) error {
+	status, err := loadStatusOrReset(home, name, reset)
+	if err != nil {
+		return err
+	}
+
+	service, err := newServiceFromStatus(status)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return nil
+	}
+
+	if service.IsUp() {
+		if err = service.Down(ctx); err != nil {
+			return err
+		}
+	}
+
+	return removeStatus(home, name)
+}
+
 func DisconnectCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "disconnect",
@@ -25,49 +42,52 @@ func DisconnectCmd() *cobra.Command {
 				return err
 			}
 
-			var (
-				status         = clienttypes.NewStatus()
-				statusFilePath = filepath.Join(ctx.HomeDir, "status.json")
-			)
-
-			if err = status.LoadFromPath(statusFilePath); err != nil {
+			name, err := cmd.Flags().GetString(clienttypes.FlagName)
+			if err != nil {
+				return err
+			}
+			if err = validateConnectionName(name); err != nil {
 				return err
 			}
 
-			var service clienttypes.Service
-			if status.Type == 1 {
-				var cfg wireguardtypes.Config
-				if err = json.Unmarshal(status.Info, &cfg); err != nil {
-					return err
-				}
+			all, err := cmd.Flags().GetBool(clienttypes.FlagAll)
+			if err != nil {
+				return err
+			}
 
-				service = wireguard.NewWireGuard(&cfg)
-			} else if status.Type == 2 {
-				var cfg v2raytypes.Config
-				if err = json.Unmarshal(status.Info, &cfg); err != nil {
-					return err
-				}
+			forceReset, err := cmd.Flags().GetBool(clienttypes.FlagForceReset)
+			if err != nil {
+				return err
+			}
 
-				service = v2ray.NewV2Ray(&cfg)
-			} else {
-				return nil
+			lock, err := acquireLock(ctx.HomeDir)
+			if err != nil {
+				return err
 			}
 
-			if service.IsUp() {
-				if err = service.PreDown(); err != nil {
-					return err
-				}
-				if err = service.Down(); err != nil {
+			defer func() { _ = lock.Release() }()
+
+			names := []string{name}
+			if all {
+				names, err = listConnections(ctx.HomeDir)
+				if err != nil {
 					return err
 				}
-				if err = service.PostDown(); err != nil {
+			}
+
+			for _, item := range names {
+				if err = disconnect(cmd.Context(), ctx.HomeDir, item, forceReset); err != nil {
 					return err
 				}
 			}
 
-			return os.Remove(statusFilePath)
+			return nil
 		},
 	}
 
+	cmd.Flags().String(clienttypes.FlagName, defaultConnectionName, "name of the connection")
+	cmd.Flags().Bool(clienttypes.FlagAll, false, "disconnect all the connections")
+	cmd.Flags().Bool(clienttypes.FlagForceReset, false, "discard a corrupt status of the connection")
+
 	return cmd
 }
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	hubtypes "github.com/sentinel-official/hub/types"
+	"github.com/spf13/cobra"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+func DoctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor [subscription] [address]",
+		Short: "Check the prerequisites for connecting to a node",
+		Args:  cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var (
+				id      uint64
+				address hubtypes.NodeAddress
+			)
+
+			if len(args) > 0 {
+				id, err = strconv.ParseUint(args[0], 10, 64)
+				if err != nil {
+					return err
+				}
+			}
+			if len(args) > 1 {
+				address, err = hubtypes.NodeAddressFromBech32(args[1])
+				if err != nil {
+					return err
+				}
+			}
+
+			v2RayProxyPort, err := cmd.Flags().GetUint16(clienttypes.FlagV2RayProxyPort)
+			if err != nil {
+				return err
+			}
+
+			checks := runPreflight(
+				ctx,
+				clienttypes.Services(),
+				id,
+				address,
+				&clienttypes.ServiceOptions{
+					ProxyPort: v2RayProxyPort,
+				},
+			)
+
+			renderChecks(cmd, checks)
+
+			if failed := checks.Failed(); len(failed) > 0 {
+				return fmt.Errorf("%d of the checks failed", len(failed))
+			}
+
+			return nil
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	cmd.Flags().String(flags.FlagChainID, "sentinelhub-2", "the network chain identity")
+	cmd.Flags().Uint16(clienttypes.FlagV2RayProxyPort, 1080, "port number for the V2Ray SOCKS proxy")
+
+	return cmd
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/olekukonko/tablewriter"
+	hubtypes "github.com/sentinel-official/hub/types"
+	nodetypes "github.com/sentinel-official/hub/x/node/types"
+	sessiontypes "github.com/sentinel-official/hub/x/session/types"
+	"github.com/spf13/cobra"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	nodeapi "github.com/sentinel-official/cli-client/x/node/api"
+	nodeinfotypes "github.com/sentinel-official/cli-client/x/node/types"
+)
+
+var (
+	planHeader = []string{
+		"Step",
+		"Details",
+	}
+	messagesHeader = []string{
+		"Message",
+		"Details",
+	}
+)
+
+// connectPlan is what a connect would do, computed without signing anything
+// or modifying the system.
+type connectPlan struct {
+	steps    [][]string
+	messages []sdk.Msg
+	gas      uint64
+	fees     sdk.Coins
+	config   string
+}
+
+func (p *connectPlan) add(step, format string, args ...interface{}) {
+	p.steps = append(p.steps, []string{step, fmt.Sprintf(format, args...)})
+}
+
+// estimateFees simulates the transaction with the messages and returns the
+// adjusted gas, along with the fees for it unless given explicitly.
+func estimateFees(cmd *cobra.Command, ctx client.Context, messages ...sdk.Msg) (uint64, sdk.Coins, error) {
+	txf, err := tx.NewFactoryCLI(ctx, cmd.Flags()).Prepare(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	_, gas, err := tx.CalculateGas(ctx, txf, messages...)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !txf.Fees().IsZero() {
+		return gas, txf.Fees(), nil
+	}
+
+	fees := sdk.NewCoins()
+	for _, price := range txf.GasPrices() {
+		amount := price.Amount.MulInt64(int64(gas)).Ceil().RoundInt()
+		fees = fees.Add(sdk.NewCoin(price.Denom, amount))
+	}
+
+	return gas, fees, nil
+}
+
+// planConnect returns the plan of a connect. On an error, the plan holds the
+// steps computed before it.
+func planConnect(
+	cmd *cobra.Command, ctx client.Context, apiClient *nodeapi.Client, name string, id uint64,
+	address hubtypes.NodeAddress, reuseSession bool, opts *clienttypes.ServiceOptions,
+) (*connectPlan, error) {
+	plan := &connectPlan{}
+
+	status, err := readStatus(ctx.HomeDir, name)
+	if err != nil {
+		return plan, err
+	}
+
+	service, err := newServiceFromStatus(status)
+	if err != nil {
+		return plan, err
+	}
+	if service != nil && service.IsUp() {
+		plan.add("Disconnect", "bring down the %s connection %s to node %s", clienttypes.ServiceName(status.Type), name, status.To)
+	}
+
+	node, err := queryNode(nodetypes.NewQueryServiceClient(ctx), address)
+	if err != nil {
+		return plan, err
+	}
+
+	plan.add("Node", "%s at %s, status %s", node.Address, node.RemoteURL, node.Status)
+
+	info, err := apiClient.FetchInfo(cmd.Context(), node.Address, node.RemoteURL)
+	if err != nil {
+		return plan, err
+	}
+
+	plan.add("Node status", "%s (%s) version %s, %d peers, latency %s",
+		info.Moniker, clienttypes.ServiceName(info.Type), info.Version, info.Peers, info.Latency)
+
+	mismatches := nodeinfotypes.Verify(node, info)
+	for _, item := range mismatches {
+		plan.add("Verification", "%s", item)
+	}
+	if len(mismatches.Fatal()) > 0 {
+		plan.add("Verification", "connect would be refused")
+		return plan, nil
+	}
+
+	definition, err := clienttypes.GetService(info.Type)
+	if err != nil {
+		return plan, err
+	}
+
+	for _, item := range runPreflight(ctx, []clienttypes.ServiceDefinition{definition}, id, address, opts) {
+		plan.add("Check", "%s is %s, %s", item.Name, item.Status, item.Message)
+	}
+
+	var (
+		qsc    = sessiontypes.NewQueryServiceClient(ctx)
+		reused *sessiontypes.Session
+	)
+
+	if reuseSession {
+		reused, err = queryReusableSession(qsc, status, ctx.FromAddress, id, address)
+		if err != nil {
+			return plan, err
+		}
+		if reused != nil {
+			plan.add("Session", "reuse the active session %d, no transaction is needed", reused.ID)
+		}
+	}
+
+	if reused == nil {
+		session, err := queryActiveSession(qsc, ctx.FromAddress)
+		if err != nil {
+			return plan, err
+		}
+
+		ownedSessions, err := sessionsOfOtherConnections(ctx.HomeDir, name)
+		if err != nil {
+			return plan, err
+		}
+
+		if session != nil {
+			if ownedSessions[session.ID] {
+				plan.add("Session", "keep the active session %d used by another connection", session.ID)
+			} else {
+				plan.add("Session", "end the active session %d", session.ID)
+				plan.messages = append(plan.messages, sessiontypes.NewMsgEndRequest(ctx.FromAddress, session.ID, 0))
+			}
+		}
+
+		plan.add("Session", "start a session for the subscription %d", id)
+		plan.messages = append(plan.messages, sessiontypes.NewMsgStartRequest(ctx.FromAddress, id, address))
+
+		plan.gas, plan.fees, err = estimateFees(cmd, ctx, plan.messages...)
+		if err != nil {
+			plan.add("Fees", "unknown, the simulation of the transaction failed: %s", err)
+		} else {
+			plan.add("Fees", "%d gas, %s", plan.gas, plan.fees)
+		}
+	}
+
+	_, secret, err := definition.GenerateKey()
+	if err != nil {
+		return plan, err
+	}
+
+	plan.add("Key exchange", "send a new %s key to %s", definition.Name, node.RemoteURL)
+
+	service, err = definition.ParseResult(definition.PlaceholderResult, secret, opts)
+	if err != nil {
+		plan.add("Up", "the %s service cannot be configured: %s", definition.Name, err)
+		return plan, nil
+	}
+
+	if v, ok := service.(clienttypes.ConfigRenderer); ok {
+		plan.config, err = v.RenderConfig()
+		if err != nil {
+			return plan, err
+		}
+	}
+
+	plan.add("Up", "bring up the %s service with the config below", definition.Name)
+	return plan, nil
+}
+
+func (p *connectPlan) render(cmd *cobra.Command) {
+	if len(p.steps) == 0 {
+		return
+	}
+
+	table := tablewriter.NewWriter(cmd.OutOrStdout())
+	table.SetHeader(planHeader)
+	table.SetAutoWrapText(false)
+	table.AppendBulk(p.steps)
+	table.Render()
+
+	if len(p.messages) > 0 {
+		table = tablewriter.NewWriter(cmd.OutOrStdout())
+		table.SetHeader(messagesHeader)
+
+		for _, msg := range p.messages {
+			table.Append(
+				[]string{
+					sdk.MsgTypeURL(msg),
+					msg.String(),
+				},
+			)
+		}
+
+		table.Render()
+	}
+
+	if p.config != "" {
+		cmd.Println("Config (the values assigned by the node are zeroed):")
+		cmd.Println(p.config)
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sessiontypes "github.com/sentinel-official/hub/x/session/types"
+	"github.com/spf13/cobra"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	osutil "github.com/sentinel-official/cli-client/utils/os"
+)
+
+const (
+	stepStartSession = "start-session"
+	stepExchangeKey  = "exchange-key"
+	stepUp           = "up"
+	stepDone         = "done"
+
+	// maxKeyConflicts is the number of times the node may report the key of
+	// the session as already added before the session is given up
+	maxKeyConflicts = 3
+)
+
+// handshake is the progress of a connect for --resume, holding the secret.
+type handshake struct {
+	Step      string    `json:"step"`
+	ID        uint64    `json:"id"`
+	Node      string    `json:"node"`
+	Session   uint64    `json:"session"`
+	Reused    bool      `json:"reused"`
+	Key       string    `json:"key"`
+	Secret    []byte    `json:"secret"`
+	Result    []byte    `json:"result"`
+	Conflicts int       `json:"conflicts"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func handshakeFilePath(home, name string) string {
+	return filepath.Join(connectionsDir(home), name+".handshake")
+}
+
+func loadHandshake(home, name string) (*handshake, error) {
+	data, err := os.ReadFile(handshakeFilePath(home, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var item handshake
+	if err = json.Unmarshal(data, &item); err != nil {
+		return nil, fmt.Errorf("%w %s: %s", clienttypes.ErrorCorruptStatus, handshakeFilePath(home, name), err)
+	}
+
+	return &item, nil
+}
+
+func saveHandshake(home, name string, item *handshake) error {
+	item.UpdatedAt = time.Now().UTC()
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(connectionsDir(home), 0700); err != nil {
+		return err
+	}
+
+	return osutil.WriteFileAtomic(handshakeFilePath(home, name), data, 0600)
+}
+
+func removeHandshake(home, name string) error {
+	err := os.Remove(handshakeFilePath(home, name))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func endSession(cmd *cobra.Command, ctx client.Context, id uint64) error {
+	return tx.GenerateOrBroadcastTxCLI(
+		ctx, cmd.Flags(),
+		sessiontypes.NewMsgEndRequest(ctx.FromAddress, id, 0),
+	)
+}
+
+// sessionNotFound reports whether the node does not know the session yet.
+func sessionNotFound(err *clienttypes.Error) bool {
+	s := strings.ToLower(err.Message)
+	return strings.Contains(s, "does not exist") || strings.Contains(s, "not found")
+}
+
+// keyConflict reports whether the node already has a key for the session,
+// which is the case when the response to an earlier key exchange was lost.
+func keyConflict(err *clienttypes.Error) bool {
+	return strings.Contains(strings.ToLower(err.Message), "already exist")
+}
+
+// definitiveRejection reports whether the node refused the key for good, so
+// that the session cannot be used with it.
+func definitiveRejection(err *clienttypes.Error) bool {
+	return !sessionNotFound(err) && !keyConflict(err)
+}
+
+// rollbackHandshake ends the session started by a connect which failed with
+// an error that cannot be recovered from by resuming it.
+func rollbackHandshake(cmd *cobra.Command, ctx client.Context, name string, item *handshake, cause error) error {
+	cmd.PrintErrf("Connect failed: %s; ending the session %d\n", cause, item.Session)
+
+	if err := endSession(cmd, ctx, item.Session); err != nil {
+		return fmt.Errorf("%w; failed to end the session %d: %s", cause, item.Session, err)
+	}
+
+	if err := removeHandshake(ctx.HomeDir, name); err != nil {
+		return err
+	}
+
+	return cause
+}
+
+func resumableError(name string, cause error) error {
+	return fmt.Errorf("%w; pass --%s --%s %s to continue the connect", cause, clienttypes.FlagResume, clienttypes.FlagName, name)
+}
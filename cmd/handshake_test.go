@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+func TestDefinitiveRejection(t *testing.T) {
+	tests := []struct {
+		message  string
+		conflict bool
+		want     bool
+	}{
+		{"session 7 does not exist", false, false},
+		{"peer already exists", true, false},
+		{"invalid signature", false, true},
+	}
+
+	for _, tc := range tests {
+		err := clienttypes.NewError("session", 1, tc.message)
+		if got := keyConflict(err); got != tc.conflict {
+			t.Errorf("keyConflict(%q) = %t, want %t", tc.message, got, tc.conflict)
+		}
+		if got := definitiveRejection(err); got != tc.want {
+			t.Errorf("definitiveRejection(%q) = %t, want %t", tc.message, got, tc.want)
+		}
+	}
+}
+
+func TestHandshakeFile(t *testing.T) {
+	home := t.TempDir()
+
+	saved := &handshake{Step: stepExchangeKey, ID: 1, Node: "sentnode1a", Session: 7, Secret: []byte("secret")}
+	if err := saveHandshake(home, "default", saved); err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := loadHandshake(home, "default")
+	if err != nil || !reflect.DeepEqual(item, saved) {
+		t.Errorf("loadHandshake() = %+v, %v, want %+v", item, err, saved)
+	}
+
+	if err = removeHandshake(home, "default"); err != nil {
+		t.Fatal(err)
+	}
+	if item, err = loadHandshake(home, "default"); item != nil || err != nil {
+		t.Errorf("loadHandshake() after removing = %+v, %v, want none", item, err)
+	}
+}
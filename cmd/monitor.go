@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	subscriptiontypes "github.com/sentinel-official/hub/x/subscription/types"
+	"github.com/spf13/cobra"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	netutil "github.com/sentinel-official/cli-client/utils/net"
+)
+
+const (
+	liveInterval            = 1 * time.Second
+	monitorInterval         = 5 * time.Second
+	allocationCheckInterval = 1 * time.Minute
+)
+
+// usageLimits are enforced by the connect command itself while it keeps running
+// in the foreground, since there is no daemon which outlives it.
+type usageLimits struct {
+	maxBytes    int64
+	maxDuration time.Duration
+	thresholds  []int
+}
+
+func (l usageLimits) isZero() bool {
+	return l.maxBytes == 0 && l.maxDuration == 0
+}
+
+func readLimits(cmd *cobra.Command) (l usageLimits, err error) {
+	maxBytes, err := cmd.Flags().GetString(clienttypes.FlagMaxBytes)
+	if err != nil {
+		return l, err
+	}
+	if maxBytes != "" {
+		l.maxBytes, err = netutil.ParseBytes(maxBytes)
+		if err != nil {
+			return l, err
+		}
+	}
+
+	l.maxDuration, err = cmd.Flags().GetDuration(clienttypes.FlagMaxDuration)
+	if err != nil {
+		return l, err
+	}
+
+	l.thresholds, err = cmd.Flags().GetIntSlice(clienttypes.FlagWarnThresholds)
+	if err != nil {
+		return l, err
+	}
+
+	for _, v := range l.thresholds {
+		if v <= 0 || v >= 100 {
+			return l, fmt.Errorf("invalid threshold %d, must be a percentage between 0 and 100", v)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.IntSlice(l.thresholds)))
+	return l, nil
+}
+
+// warnAllocation prints a warning once for the lowest threshold of the
+// remaining allocation of the subscription which has been crossed.
+func warnAllocation(cmd *cobra.Command, ctx client.Context, id uint64, thresholds []int, warned map[int]bool) {
+	qsc := subscriptiontypes.NewQueryServiceClient(ctx)
+
+	result, err := qsc.QueryAllocation(
+		context.Background(),
+		subscriptiontypes.NewQueryAllocationRequest(id, ctx.FromAddress),
+	)
+	if err != nil {
+		return
+	}
+
+	granted := result.Allocation.GrantedBytes
+	if !granted.IsPositive() {
+		return
+	}
+
+	var (
+		left    = granted.Sub(result.Allocation.UtilisedBytes)
+		percent = int(left.MulRaw(100).Quo(granted).Int64())
+		crossed = 0
+	)
+
+	for _, v := range thresholds {
+		if percent <= v && !warned[v] {
+			warned[v], crossed = true, v
+		}
+	}
+
+	if crossed != 0 {
+		cmd.PrintErrf("WARNING: %d%% (%s) of the allocation of the subscription %d is left\n",
+			percent, netutil.ToReadable(left.Int64(), 2), id)
+	}
+}
+
+// stopConnection disconnects the connection, and ends its session if asked.
+func stopConnection(cmd *cobra.Command, ctx client.Context, name string, session uint64, end bool) error {
+	lock, err := acquireLock(ctx.HomeDir)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = lock.Release() }()
+
+	if err = disconnect(context.Background(), ctx.HomeDir, name, false); err != nil {
+		return err
+	}
+	if !end {
+		return nil
+	}
+
+	return endSession(cmd, ctx, session)
+}
+
+// monitor runs a connection in the foreground, enforcing its limits.
+type monitor struct {
+	name       string
+	id         uint64
+	session    uint64
+	moniker    string
+	limits     usageLimits
+	live       bool
+	endSession bool
+}
+
+func (m *monitor) printLive(cmd *cobra.Command, stats *clienttypes.Stats, upRate, downRate float64) {
+	cmd.Printf("\r%-100s",
+		fmt.Sprintf("%s | up %s/s (%s) | down %s/s (%s) | %s",
+			m.moniker,
+			netutil.ToReadable(int64(upRate), 2), netutil.ToReadable(stats.Upload, 2),
+			netutil.ToReadable(int64(downRate), 2), netutil.ToReadable(stats.Download, 2),
+			stats.Uptime.Truncate(time.Second),
+		),
+	)
+}
+
+func (m *monitor) run(cmd *cobra.Command, ctx client.Context) error {
+	interval := monitorInterval
+	if m.live {
+		interval = liveInterval
+	}
+
+	c, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var (
+		startTime           = time.Now()
+		ticker              = time.NewTicker(interval)
+		lastAllocationCheck time.Time
+		last                = &clienttypes.Stats{}
+		lastTime            = time.Now()
+		lastState           string
+		warned              = make(map[int]bool)
+	)
+
+	defer ticker.Stop()
+
+	if !m.limits.isZero() {
+		cmd.Printf("Enforcing the limits of the connection %s, keep this command running\n", m.name)
+	}
+
+	for {
+		select {
+		case <-c.Done():
+			if m.live {
+				cmd.Println()
+			}
+
+			cmd.Printf("Interrupted; disconnecting %s\n", m.name)
+			return stopConnection(cmd, ctx, m.name, m.session, m.endSession)
+		case <-ticker.C:
+		}
+
+		status, err := loadStatus(ctx.HomeDir, m.name)
+		if err != nil {
+			return err
+		}
+		if status.Session != m.session {
+			if m.live {
+				cmd.Println()
+			}
+
+			cmd.Printf("Connection %s was disconnected by another command\n", m.name)
+			return nil
+		}
+
+		service, err := newServiceFromStatus(status)
+		if err != nil {
+			return err
+		}
+
+		// The stats of a tunnel which is gone would be stale, so its health is
+		// checked first and the monitoring stops once it is down
+		health, err := service.Health(c)
+		if c.Err() != nil {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check the health of the connection %s: %w", m.name, err)
+		}
+		if health.State == clienttypes.HealthStateDown {
+			if m.live {
+				cmd.Println()
+			}
+
+			return fmt.Errorf("connection %s is down, disconnect it to clean up and connect again", m.name)
+		}
+		if health.State == clienttypes.HealthStateDegraded && lastState == clienttypes.HealthStateUp {
+			if m.live {
+				cmd.Println()
+			}
+
+			cmd.PrintErrf("WARNING: connection %s is degraded, the tunnel is up but not passing traffic\n", m.name)
+		}
+
+		lastState = health.State
+
+		stats, err := service.Stats()
+		if err != nil {
+			stats = last
+		}
+
+		if m.live {
+			elapsed := time.Since(lastTime).Seconds()
+			m.printLive(cmd, stats,
+				float64(stats.Upload-last.Upload)/elapsed,
+				float64(stats.Download-last.Download)/elapsed,
+			)
+		}
+
+		last, lastTime = stats, time.Now()
+
+		var reason string
+		switch {
+		case m.limits.maxBytes > 0 && stats.Upload+stats.Download >= m.limits.maxBytes:
+			reason = fmt.Sprintf("Data cap of %s reached", netutil.ToReadable(m.limits.maxBytes, 2))
+		case m.limits.maxDuration > 0 && time.Since(startTime) >= m.limits.maxDuration:
+			reason = fmt.Sprintf("Time limit of %s reached", m.limits.maxDuration)
+		}
+
+		if reason != "" {
+			if m.live {
+				cmd.Println()
+			}
+
+			cmd.Printf("%s; disconnecting %s and ending the session %d\n", reason, m.name, m.session)
+			return stopConnection(cmd, ctx, m.name, m.session, true)
+		}
+
+		if len(m.limits.thresholds) > 0 && time.Since(lastAllocationCheck) >= allocationCheckInterval {
+			lastAllocationCheck = time.Now()
+			warnAllocation(cmd, ctx, m.id, m.limits.thresholds, warned)
+		}
+	}
+}
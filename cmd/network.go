@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/olekukonko/tablewriter"
+	nodetypes "github.com/sentinel-official/hub/x/node/types"
+	"github.com/spf13/cobra"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	nodeapi "github.com/sentinel-official/cli-client/x/node/api"
+	nodeinfotypes "github.com/sentinel-official/cli-client/x/node/types"
+)
+
+const (
+	flagGroupBy = "group-by"
+
+	groupByCity    = "city"
+	groupByCountry = "country"
+)
+
+func NetworkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "network",
+		Short:                      "Network subcommands",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		networkSummaryCmd(),
+	)
+
+	return cmd
+}
+
+func formatTypes(types map[string]int) string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	items := make([]string, 0, len(names))
+	for _, name := range names {
+		items = append(items, fmt.Sprintf("%s %d", name, types[name]))
+	}
+
+	return strings.Join(items, ", ")
+}
+
+func formatMedianPrice(v int64, denom string) string {
+	if v == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%d%s", v, denom)
+}
+
+func networkSummaryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "summary",
+		Short: "Summarize the active nodes by their country and city",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			apiConfig, err := nodeapi.NewConfigFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
+			strictTLS, err := cmd.Flags().GetBool(clienttypes.FlagTLSStrict)
+			if err != nil {
+				return err
+			}
+
+			concurrency, err := cmd.Flags().GetInt(flagConcurrency)
+			if err != nil {
+				return err
+			}
+			if concurrency < 1 {
+				return fmt.Errorf("invalid concurrency %d", concurrency)
+			}
+
+			cacheTTL, err := cmd.Flags().GetDuration(clienttypes.FlagCacheTTL)
+			if err != nil {
+				return err
+			}
+
+			denom, err := cmd.Flags().GetString(flagDenom)
+			if err != nil {
+				return err
+			}
+
+			groupBy, err := cmd.Flags().GetString(flagGroupBy)
+			if err != nil {
+				return err
+			}
+			if groupBy != groupByCity && groupBy != groupByCountry {
+				return fmt.Errorf("invalid group %s, expected one of %s, %s", groupBy, groupByCountry, groupByCity)
+			}
+
+			output, err := cmd.Flags().GetString(tmcli.OutputFlag)
+			if err != nil {
+				return err
+			}
+			if output != "text" && output != "json" {
+				return fmt.Errorf("invalid output %s, expected one of text, json", output)
+			}
+
+			cache := nodeinfotypes.NewCache(ctx.HomeDir)
+			if err = cache.Load(); err != nil {
+				return err
+			}
+
+			pins := clienttypes.NewPinStore(ctx.HomeDir, cmd.ErrOrStderr())
+			if err = pins.Load(); err != nil {
+				return err
+			}
+
+			items, err := queryActiveNodes(cmd, ctx, 0)
+			if err != nil {
+				return err
+			}
+
+			var (
+				apiClient = nodeapi.NewClient(apiConfig).WithTLSConfig(pins.TLSConfigFunc(strictTLS))
+				nodes     = make(nodeinfotypes.Nodes, 0, len(items))
+				online    = make(map[string]bool)
+				stale     []nodetypes.Node
+			)
+
+			defer apiClient.CloseIdleConnections()
+
+			for i := 0; i < len(items); i++ {
+				if info, ok := cache.Get(items[i].Address, cacheTTL); ok {
+					nodes = append(nodes, nodeinfotypes.NewNodeFromRaw(&items[i]).WithInfo(info))
+					online[items[i].Address] = true
+				} else {
+					stale = append(stale, items[i])
+				}
+			}
+
+			// The unreachable nodes are placed where they were last reported
+			apiClient.FetchInfos(cmd.Context(), stale, concurrency,
+				func(node *nodetypes.Node, info nodeinfotypes.Info, err error) {
+					cache.Put(node.Address, info, err)
+					if err != nil {
+						entry, _ := cache.Entry(node.Address)
+						info = entry.Info
+					} else {
+						online[node.Address] = true
+					}
+
+					nodes = append(nodes, nodeinfotypes.NewNodeFromRaw(node).WithInfo(info))
+				},
+			)
+
+			if err = pins.Save(); err != nil {
+				return err
+			}
+			if err = cache.Save(); err != nil {
+				return err
+			}
+
+			summaries := nodeinfotypes.Summarize(nodes, online, groupBy == groupByCity, denom)
+			if output == "json" {
+				return json.NewEncoder(cmd.OutOrStdout()).Encode(summaries)
+			}
+
+			header := []string{"Country"}
+			if groupBy == groupByCity {
+				header = append(header, "City")
+			}
+
+			header = append(header,
+				"Nodes",
+				"Types",
+				"Online",
+				"Median gigabyte price",
+				"Median hourly price",
+				"Median latency",
+				"Peers",
+			)
+
+			table := tablewriter.NewWriter(cmd.OutOrStdout())
+			table.SetHeader(header)
+
+			for _, item := range summaries {
+				row := []string{item.Country}
+				if groupBy == groupByCity {
+					row = append(row, item.City)
+				}
+
+				row = append(row,
+					fmt.Sprintf("%d", item.Nodes),
+					formatTypes(item.Types),
+					fmt.Sprintf("%d/%d (%.0f%%)", item.Online, item.Nodes, item.OnlineRatio()*100),
+					formatMedianPrice(item.MedianGigabytePrice, denom),
+					formatMedianPrice(item.MedianHourlyPrice, denom),
+					item.MedianLatency.Truncate(time.Millisecond).String(),
+					fmt.Sprintf("%d", item.Peers),
+				)
+
+				table.Append(row)
+			}
+
+			table.Render()
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	nodeapi.AddFlagsToCmd(cmd)
+
+	cmd.Flags().String(flagGroupBy, groupByCity, "group the nodes by country or city")
+	cmd.Flags().String(flagDenom, "udvpn", "denom of the median prices")
+	cmd.Flags().Int(flagConcurrency, 16, "number of nodes probed at the same time")
+	cmd.Flags().Bool(clienttypes.FlagTLSStrict, false, "fail if the certificate of a node does not match the pinned one")
+	cmd.Flags().Duration(clienttypes.FlagCacheTTL, 0, "use the cached information of the nodes probed within this time instead of probing them")
+
+	return cmd
+}
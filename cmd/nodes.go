@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/olekukonko/tablewriter"
+	hubtypes "github.com/sentinel-official/hub/types"
+	nodetypes "github.com/sentinel-official/hub/x/node/types"
+	"github.com/spf13/cobra"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	nodeapi "github.com/sentinel-official/cli-client/x/node/api"
+)
+
+const (
+	flagFingerprint = "fingerprint"
+)
+
+var (
+	pinsHeader = []string{
+		"Address",
+		"Fingerprint",
+		"Pinned at",
+	}
+)
+
+// remoteAddress returns the host and port of the remote URL of a node.
+func remoteAddress(remoteURL string) (string, error) {
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		return "", err
+	}
+	if u.Port() == "" {
+		return net.JoinHostPort(u.Hostname(), "443"), nil
+	}
+
+	return u.Host, nil
+}
+
+func NodesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "nodes",
+		Short:                      "Local node management subcommands",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		nodesTrustCmd(),
+		nodesUntrustCmd(),
+		nodesPinsCmd(),
+		nodesBenchmarkCmd(),
+		nodesBenchmarksCmd(),
+		nodeListCmd("favorite", "Favourite nodes, marked in the node lists", clienttypes.NewFavorites),
+		nodeListCmd("block", "Blocked nodes, hidden from the node lists", clienttypes.NewBlocked),
+	)
+
+	return cmd
+}
+
+func nodesTrustCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trust [address]",
+		Short: "Pin the current certificate of a node",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			address, err := hubtypes.NodeAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			fingerprint, err := cmd.Flags().GetString(flagFingerprint)
+			if err != nil {
+				return err
+			}
+
+			if fingerprint != "" {
+				fingerprint = strings.ToLower(fingerprint)
+				if v, err := hex.DecodeString(fingerprint); err != nil || len(v) != sha256.Size {
+					return fmt.Errorf("invalid fingerprint %s", fingerprint)
+				}
+			} else {
+				node, err := queryNode(nodetypes.NewQueryServiceClient(ctx), address)
+				if err != nil {
+					return err
+				}
+
+				apiConfig, err := nodeapi.NewConfigFromCmd(cmd)
+				if err != nil {
+					return err
+				}
+
+				apiClient := nodeapi.NewClient(apiConfig)
+				defer apiClient.CloseIdleConnections()
+
+				cert, err := apiClient.FetchCertificate(cmd.Context(), node.Address, node.RemoteURL)
+				if err != nil {
+					return err
+				}
+
+				fingerprint = clienttypes.Fingerprint(cert)
+			}
+
+			pins := clienttypes.NewPinStore(ctx.HomeDir, cmd.ErrOrStderr())
+			if err = pins.Load(); err != nil {
+				return err
+			}
+
+			pins.Set(address.String(), fingerprint)
+			if err = pins.Save(); err != nil {
+				return err
+			}
+
+			cmd.Printf("Pinned the certificate %s for node %s\n", fingerprint, address)
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	nodeapi.AddFlagsToCmd(cmd)
+
+	cmd.Flags().String(flagFingerprint, "", "pin the given SHA-256 fingerprint instead of fetching the certificate")
+
+	return cmd
+}
+
+func nodesUntrustCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "untrust [address]",
+		Short: "Remove the pinned certificate of a node",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			address, err := hubtypes.NodeAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			pins := clienttypes.NewPinStore(ctx.HomeDir, cmd.ErrOrStderr())
+			if err = pins.Load(); err != nil {
+				return err
+			}
+
+			if !pins.Delete(address.String()) {
+				return fmt.Errorf("no certificate is pinned for node %s", address)
+			}
+
+			return pins.Save()
+		},
+	}
+
+	return cmd
+}
+
+func nodesPinsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pins",
+		Short: "List the pinned node certificates",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			pins := clienttypes.NewPinStore(ctx.HomeDir, cmd.ErrOrStderr())
+			if err = pins.Load(); err != nil {
+				return err
+			}
+
+			table := tablewriter.NewWriter(cmd.OutOrStdout())
+			table.SetHeader(pinsHeader)
+
+			for _, item := range pins.List() {
+				table.Append(
+					[]string{
+						item.Address,
+						item.Fingerprint,
+						item.PinnedAt.Format(time.RFC3339),
+					},
+				)
+			}
+
+			table.Render()
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+var (
+	nodeListHeader = []string{
+		"Address",
+		"Added at",
+	}
+)
+
+// nodeListCmd returns the commands for managing a list of nodes kept in the
+// home directory, e.g. the favourite nodes.
+func nodeListCmd(use, short string, newList func(home string) *clienttypes.NodeList) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        use,
+		Short:                      short,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	load := func(cmd *cobra.Command) (*clienttypes.NodeList, error) {
+		ctx, err := client.GetClientQueryContext(cmd)
+		if err != nil {
+			return nil, err
+		}
+
+		list := newList(ctx.HomeDir)
+		if err = list.Load(); err != nil {
+			return nil, err
+		}
+
+		return list, nil
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "add [address...]",
+			Short: "Add the nodes to the list",
+			Args:  cobra.MinimumNArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				list, err := load(cmd)
+				if err != nil {
+					return err
+				}
+
+				for _, arg := range args {
+					address, err := hubtypes.NodeAddressFromBech32(arg)
+					if err != nil {
+						return err
+					}
+
+					list.Add(address.String())
+				}
+
+				return list.Save()
+			},
+		},
+		&cobra.Command{
+			Use:   "remove [address...]",
+			Short: "Remove the nodes from the list",
+			Args:  cobra.MinimumNArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				list, err := load(cmd)
+				if err != nil {
+					return err
+				}
+
+				for _, arg := range args {
+					if !list.Remove(arg) {
+						return fmt.Errorf("node %s is not in the list", arg)
+					}
+				}
+
+				return list.Save()
+			},
+		},
+		&cobra.Command{
+			Use:   "list",
+			Short: "List the nodes",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				list, err := load(cmd)
+				if err != nil {
+					return err
+				}
+
+				table := tablewriter.NewWriter(cmd.OutOrStdout())
+				table.SetHeader(nodeListHeader)
+
+				for _, item := range list.List() {
+					table.Append(
+						[]string{
+							item.Address,
+							item.AddedAt.Format(time.RFC3339),
+						},
+					)
+				}
+
+				table.Render()
+				return nil
+			},
+		},
+	)
+
+	return cmd
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/olekukonko/tablewriter"
+	hubtypes "github.com/sentinel-official/hub/types"
+	"github.com/spf13/cobra"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+var (
+	checksHeader = []string{
+		"Check",
+		"Status",
+		"Details",
+	}
+)
+
+func checkKeyring(ctx client.Context) clienttypes.Check {
+	if ctx.GetFromName() == "" {
+		return clienttypes.NewCheck("keyring", clienttypes.CheckStatusFail, "no key given, pass --from")
+	}
+
+	key, err := ctx.Keyring.Key(ctx.GetFromName())
+	if err != nil {
+		return clienttypes.NewCheck("keyring", clienttypes.CheckStatusFail, "%s", err)
+	}
+
+	return clienttypes.NewCheck("keyring", clienttypes.CheckStatusOK, "key %s with address %s", key.GetName(), key.GetAddress())
+}
+
+func checkRPC(ctx client.Context) clienttypes.Check {
+	if ctx.Client == nil {
+		return clienttypes.NewCheck("rpc", clienttypes.CheckStatusFail, "no RPC endpoint given, pass --node")
+	}
+
+	c, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	status, err := ctx.Client.Status(c)
+	if err != nil {
+		return clienttypes.NewCheck("rpc", clienttypes.CheckStatusFail, "%s is not reachable: %s", ctx.NodeURI, err)
+	}
+	if ctx.ChainID != "" && status.NodeInfo.Network != ctx.ChainID {
+		return clienttypes.NewCheck("rpc", clienttypes.CheckStatusFail,
+			"%s is on the chain %s, expected %s", ctx.NodeURI, status.NodeInfo.Network, ctx.ChainID)
+	}
+	if status.SyncInfo.CatchingUp {
+		return clienttypes.NewCheck("rpc", clienttypes.CheckStatusWarn,
+			"%s is catching up, at the height %d", ctx.NodeURI, status.SyncInfo.LatestBlockHeight)
+	}
+
+	return clienttypes.NewCheck("rpc", clienttypes.CheckStatusOK,
+		"%s on the chain %s at the height %d", ctx.NodeURI, status.NodeInfo.Network, status.SyncInfo.LatestBlockHeight)
+}
+
+// runPreflight checks everything a connect depends on which can be verified
+// before any transaction is sent. The subscription and node are optional.
+func runPreflight(
+	ctx client.Context, definitions []clienttypes.ServiceDefinition, id uint64, address hubtypes.NodeAddress,
+	opts *clienttypes.ServiceOptions,
+) clienttypes.Checks {
+	checks := clienttypes.Checks{
+		checkKeyring(ctx),
+		checkRPC(ctx),
+	}
+
+	if id != 0 {
+		checks = append(checks, checkSubscription(ctx, id, address)...)
+	}
+
+	proxyPorts, err := proxyPortsOfConnections(ctx.HomeDir)
+	if err != nil {
+		checks = append(checks, clienttypes.NewCheck("connections", clienttypes.CheckStatusWarn, "%s", err))
+	}
+
+	options := *opts
+	options.ProxyPorts = proxyPorts
+
+	for _, definition := range definitions {
+		if definition.Preflight == nil {
+			continue
+		}
+
+		for _, item := range definition.Preflight(&options) {
+			item.Name = fmt.Sprintf("%s %s", definition.Name, item.Name)
+			checks = append(checks, item)
+		}
+	}
+
+	return checks
+}
+
+func renderChecks(cmd *cobra.Command, checks clienttypes.Checks) {
+	table := tablewriter.NewWriter(cmd.OutOrStdout())
+	table.SetHeader(checksHeader)
+
+	for _, item := range checks {
+		table.Append(
+			[]string{
+				item.Name,
+				item.Status,
+				item.Message,
+			},
+		)
+	}
+
+	table.Render()
+}
@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/olekukonko/tablewriter"
+	hubtypes "github.com/sentinel-official/hub/types"
+	plantypes "github.com/sentinel-official/hub/x/plan/types"
+	"github.com/spf13/cobra"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+const (
+	flagDenom     = "denom"
+	flagGigabytes = "gigabytes"
+	flagHours     = "hours"
+	flagLimit     = "limit"
+	flagRates     = "rates"
+
+	priceKindNode = "node"
+	priceKindPlan = "plan"
+)
+
+var (
+	pricingHeader = []string{
+		"Rank",
+		"Kind",
+		"ID",
+		"Provider",
+		"Units",
+		"Paid in",
+		"Cost",
+		"Per gigabyte",
+		"Per hour",
+	}
+)
+
+// priceOption is a way of buying the data or time; Units are gigabytes, hours or plans.
+type priceOption struct {
+	Kind        string   `json:"kind"`
+	ID          string   `json:"id"`
+	Provider    string   `json:"provider,omitempty"`
+	Units       int64    `json:"units"`
+	PaidIn      string   `json:"paid_in"`
+	Cost        float64  `json:"cost"`
+	PerGigabyte *float64 `json:"per_gigabyte,omitempty"`
+	PerHour     *float64 `json:"per_hour,omitempty"`
+}
+
+func formatAmount(v *float64, denom string) string {
+	if v == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(math.Round(*v*1e6)/1e6, 'f', -1, 64) + denom
+}
+
+// nodePriceOption returns the cost of subscribing to the node for the
+// gigabytes or the hours, whichever is not zero.
+func nodePriceOption(
+	address string, gigabytePrices, hourlyPrices clienttypes.Coins, gigabytes, hours int64, denom string, rates clienttypes.Rates,
+) (priceOption, bool) {
+	option := priceOption{
+		Kind: priceKindNode,
+		ID:   address,
+	}
+
+	if v, _, ok := rates.Lowest(gigabytePrices, denom); ok {
+		option.PerGigabyte = &v
+	}
+	if v, _, ok := rates.Lowest(hourlyPrices, denom); ok {
+		option.PerHour = &v
+	}
+
+	prices, units := gigabytePrices, gigabytes
+	if hours > 0 {
+		prices, units = hourlyPrices, hours
+	}
+
+	price, from, ok := rates.Lowest(prices, denom)
+	if !ok {
+		return option, false
+	}
+
+	option.Units, option.PaidIn, option.Cost = units, from, price*float64(units)
+	return option, true
+}
+
+// planPriceOption returns the cost of subscribing to the plan as many times
+// as needed for the gigabytes or the hours, whichever is not zero.
+func planPriceOption(plan *plantypes.Plan, gigabytes, hours int64, denom string, rates clienttypes.Rates) (priceOption, bool) {
+	price, from, ok := rates.Lowest(clienttypes.NewCoinsFromRaw(plan.Prices), denom)
+	if !ok {
+		return priceOption{}, false
+	}
+
+	var (
+		option = priceOption{
+			Kind:     priceKindPlan,
+			ID:       strconv.FormatUint(plan.ID, 10),
+			Provider: plan.ProviderAddress,
+			PaidIn:   from,
+		}
+		planHours = plan.Duration.Hours()
+	)
+
+	if plan.Gigabytes > 0 {
+		v := price / float64(plan.Gigabytes)
+		option.PerGigabyte = &v
+	}
+	if planHours > 0 {
+		v := price / planHours
+		option.PerHour = &v
+	}
+
+	switch {
+	case gigabytes > 0 && plan.Gigabytes > 0:
+		option.Units = (gigabytes + plan.Gigabytes - 1) / plan.Gigabytes
+	case hours > 0 && planHours > 0:
+		option.Units = int64(math.Ceil(float64(hours) / planHours))
+	default:
+		return option, false
+	}
+
+	option.Cost = price * float64(option.Units)
+	return option, true
+}
+
+func PricingCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pricing",
+		Short: "Compare the cost of the desired data or time over the node subscriptions and the plans",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			gigabytes, err := cmd.Flags().GetInt64(flagGigabytes)
+			if err != nil {
+				return err
+			}
+
+			hours, err := cmd.Flags().GetInt64(flagHours)
+			if err != nil {
+				return err
+			}
+
+			if gigabytes < 0 || hours < 0 || (gigabytes > 0) == (hours > 0) {
+				return fmt.Errorf("pass either --%s or --%s", flagGigabytes, flagHours)
+			}
+
+			denom, err := cmd.Flags().GetString(flagDenom)
+			if err != nil {
+				return err
+			}
+
+			limit, err := cmd.Flags().GetInt(flagLimit)
+			if err != nil {
+				return err
+			}
+
+			output, err := cmd.Flags().GetString(tmcli.OutputFlag)
+			if err != nil {
+				return err
+			}
+			if output != "text" && output != "json" {
+				return fmt.Errorf("invalid output %s, expected one of text, json", output)
+			}
+
+			var rates clienttypes.Rates
+
+			path, err := cmd.Flags().GetString(flagRates)
+			if err != nil {
+				return err
+			}
+			if path != "" {
+				rates, err = clienttypes.LoadRates(path)
+				if err != nil {
+					return err
+				}
+				if _, ok := rates[denom]; !ok {
+					return fmt.Errorf("rates file %s has no rate of denom %s", path, denom)
+				}
+			}
+
+			blocked := clienttypes.NewBlocked(ctx.HomeDir)
+			if err = blocked.Load(); err != nil {
+				return err
+			}
+
+			nodes, err := queryActiveNodes(cmd, ctx, 0)
+			if err != nil {
+				return err
+			}
+
+			qsc := plantypes.NewQueryServiceClient(ctx)
+
+			plans, err := clienttypes.Paginate(cmd.Context(), nil, clienttypes.DefaultPaginateOptions(),
+				func(pagination *query.PageRequest) ([]plantypes.Plan, *query.PageResponse, error) {
+					result, err := qsc.QueryPlans(
+						context.Background(),
+						plantypes.NewQueryPlansRequest(hubtypes.StatusActive, pagination),
+					)
+					if err != nil {
+						return nil, nil, err
+					}
+
+					return result.Plans, result.Pagination, nil
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			var options []priceOption
+			for i := 0; i < len(nodes); i++ {
+				if blocked.Has(nodes[i].Address) {
+					continue
+				}
+
+				option, ok := nodePriceOption(
+					nodes[i].Address,
+					clienttypes.NewCoinsFromRaw(nodes[i].GigabytePrices),
+					clienttypes.NewCoinsFromRaw(nodes[i].HourlyPrices),
+					gigabytes, hours, denom, rates,
+				)
+				if ok {
+					options = append(options, option)
+				}
+			}
+			for i := 0; i < len(plans); i++ {
+				if option, ok := planPriceOption(&plans[i], gigabytes, hours, denom, rates); ok {
+					options = append(options, option)
+				}
+			}
+
+			if len(options) == 0 {
+				return errors.New("no node or plan has prices which can be compared in " + denom)
+			}
+
+			sort.SliceStable(options, func(i, j int) bool {
+				if options[i].Cost != options[j].Cost {
+					return options[i].Cost < options[j].Cost
+				}
+
+				return options[i].ID < options[j].ID
+			})
+
+			if limit > 0 && len(options) > limit {
+				options = options[:limit]
+			}
+
+			if output == "json" {
+				return json.NewEncoder(cmd.OutOrStdout()).Encode(options)
+			}
+
+			table := tablewriter.NewWriter(cmd.OutOrStdout())
+			table.SetHeader(pricingHeader)
+
+			for i, item := range options {
+				table.Append(
+					[]string{
+						fmt.Sprintf("%d", i+1),
+						item.Kind,
+						item.ID,
+						item.Provider,
+						fmt.Sprintf("%d", item.Units),
+						item.PaidIn,
+						formatAmount(&item.Cost, denom),
+						formatAmount(item.PerGigabyte, denom),
+						formatAmount(item.PerHour, denom),
+					},
+				)
+			}
+
+			table.Render()
+			return nil
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	cmd.Flags().Int64(flagGigabytes, 0, "desired amount of data in gigabytes")
+	cmd.Flags().Int64(flagHours, 0, "desired amount of time in hours")
+	cmd.Flags().String(flagDenom, "udvpn", "preferred denom the costs are compared in")
+	cmd.Flags().String(flagRates, "", "JSON file of the values of the denoms in a common unit, for comparing the prices in the other denoms")
+	cmd.Flags().Int(flagLimit, 25, "number of the cheapest options listed, 0 for all")
+
+	return cmd
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	plantypes "github.com/sentinel-official/hub/x/plan/types"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+func TestNodePriceOption(t *testing.T) {
+	var (
+		rates          = clienttypes.Rates{"udvpn": 1, "uatom": 8}
+		gigabytePrices = clienttypes.Coins{{Denom: "udvpn", Value: 100}, {Denom: "uatom", Value: 10}}
+	)
+
+	option, ok := nodePriceOption("sentnode1a", gigabytePrices, nil, 5, 0, "udvpn", rates)
+	if !ok || option.Units != 5 || option.PaidIn != "uatom" || option.Cost != 400 {
+		t.Errorf("gigabytes: %+v, %t, want 5 gigabytes paid in uatom for 400", option, ok)
+	}
+
+	if _, ok = nodePriceOption("sentnode1a", gigabytePrices, nil, 0, 3, "udvpn", rates); ok {
+		t.Error("hours of a node without hourly prices have a price")
+	}
+}
+
+func TestPlanPriceOption(t *testing.T) {
+	plan := &plantypes.Plan{
+		ID:        1,
+		Prices:    sdk.NewCoins(sdk.NewInt64Coin("udvpn", 7200)),
+		Duration:  30 * 24 * time.Hour,
+		Gigabytes: 10,
+	}
+
+	tests := []struct {
+		gigabytes, hours int64
+		wantUnits        int64
+	}{
+		{25, 0, 3},
+		{20, 0, 2},
+		{0, 721, 2},
+	}
+
+	for _, tc := range tests {
+		option, ok := planPriceOption(plan, tc.gigabytes, tc.hours, "udvpn", nil)
+		if !ok || option.Units != tc.wantUnits || option.Cost != float64(tc.wantUnits)*7200 {
+			t.Errorf("%d gigabytes, %d hours: %+v, %t, want %d subscriptions", tc.gigabytes, tc.hours, option, ok, tc.wantUnits)
+		}
+		if *option.PerGigabyte != 720 || *option.PerHour != 10 {
+			t.Errorf("unit prices %v, %v, want 720, 10", *option.PerGigabyte, *option.PerHour)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	netutil "github.com/sentinel-official/cli-client/utils/net"
+)
+
+var (
+	statusHeader = []string{
+		"Name",
+		"From",
+		"Subscription",
+		"Session",
+		"Node",
+		"Type",
+		"State",
+		"Upload",
+		"Download",
+		"Uptime",
+	}
+)
+
+func StatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of the connections",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			name, err := cmd.Flags().GetString(clienttypes.FlagName)
+			if err != nil {
+				return err
+			}
+			if err = validateConnectionName(name); err != nil {
+				return err
+			}
+
+			all, err := cmd.Flags().GetBool(clienttypes.FlagAll)
+			if err != nil {
+				return err
+			}
+
+			names := []string{name}
+			if all {
+				names, err = listConnections(ctx.HomeDir)
+				if err != nil {
+					return err
+				}
+			}
+
+			table := tablewriter.NewWriter(cmd.OutOrStdout())
+			table.SetHeader(statusHeader)
+
+			for _, item := range names {
+				status, err := loadStatus(ctx.HomeDir, item)
+				if err != nil {
+					return err
+				}
+
+				service, err := newServiceFromStatus(status)
+				if err != nil {
+					return err
+				}
+				if service == nil {
+					continue
+				}
+
+				var (
+					state = clienttypes.HealthStateDown
+					stats = &clienttypes.Stats{}
+				)
+
+				health, err := service.Health(cmd.Context())
+				if err == nil {
+					state = health.State
+				}
+				if state != clienttypes.HealthStateDown {
+					if v, err := service.Stats(); err == nil {
+						stats = v
+					}
+				}
+
+				table.Append(
+					[]string{
+						item,
+						status.From,
+						fmt.Sprintf("%d", status.ID),
+						fmt.Sprintf("%d", status.Session),
+						status.To,
+						clienttypes.ServiceName(status.Type),
+						state,
+						netutil.ToReadable(stats.Upload, 2),
+						netutil.ToReadable(stats.Download, 2),
+						stats.Uptime.Truncate(1 * time.Second).String(),
+					},
+				)
+			}
+
+			table.Render()
+			return nil
+		},
+	}
+
+	cmd.Flags().String(clienttypes.FlagName, defaultConnectionName, "name of the connection")
+	cmd.Flags().Bool(clienttypes.FlagAll, false, "show all the connections")
+
+	return cmd
+}
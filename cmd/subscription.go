@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	hubtypes "github.com/sentinel-official/hub/types"
+	nodetypes "github.com/sentinel-official/hub/x/node/types"
+	subscriptiontypes "github.com/sentinel-official/hub/x/subscription/types"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	netutil "github.com/sentinel-official/cli-client/utils/net"
+)
+
+const (
+	maxSuggestedNodes = 3
+)
+
+func querySubscription(ctx client.Context, qsc subscriptiontypes.QueryServiceClient, id uint64) (subscriptiontypes.Subscription, error) {
+	result, err := qsc.QuerySubscription(
+		context.Background(),
+		subscriptiontypes.NewQuerySubscriptionRequest(id),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscription subscriptiontypes.Subscription
+	if err = ctx.InterfaceRegistry.UnpackAny(result.Subscription, &subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// queryPlanNode pages through the active nodes of the plan until the node is
+// found, and returns a few of the nodes to suggest otherwise.
+func queryPlanNode(ctx client.Context, id uint64, address hubtypes.NodeAddress) (found bool, nodes []string, err error) {
+	var (
+		qsc        = nodetypes.NewQueryServiceClient(ctx)
+		pagination = &query.PageRequest{Limit: 100}
+	)
+
+	for {
+		result, err := qsc.QueryNodesForPlan(
+			context.Background(),
+			nodetypes.NewQueryNodesForPlanRequest(id, hubtypes.StatusActive, pagination),
+		)
+		if err != nil {
+			return false, nil, err
+		}
+
+		for _, item := range result.Nodes {
+			if item.Address == address.String() {
+				return true, nil, nil
+			}
+			if len(nodes) < maxSuggestedNodes {
+				nodes = append(nodes, item.Address)
+			}
+		}
+
+		if result.Pagination == nil || len(result.Pagination.NextKey) == 0 {
+			return false, nodes, nil
+		}
+
+		pagination = &query.PageRequest{Key: result.Pagination.NextKey, Limit: 100}
+	}
+}
+
+// findSubscription returns an active subscription of the account which can be
+// used for connecting to the node, or zero.
+func findSubscription(ctx client.Context, qsc subscriptiontypes.QueryServiceClient, address hubtypes.NodeAddress) (uint64, error) {
+	result, err := clienttypes.Paginate(context.Background(), &query.PageRequest{Limit: 100}, clienttypes.DefaultPaginateOptions(),
+		func(pagination *query.PageRequest) ([]*codectypes.Any, *query.PageResponse, error) {
+			result, err := qsc.QuerySubscriptionsForAccount(
+				context.Background(),
+				subscriptiontypes.NewQuerySubscriptionsForAccountRequest(ctx.FromAddress, pagination),
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return result.Subscriptions, result.Pagination, nil
+		},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, item := range result {
+		var subscription subscriptiontypes.Subscription
+		if err = ctx.InterfaceRegistry.UnpackAny(item, &subscription); err != nil {
+			return 0, err
+		}
+		if !subscription.GetStatus().Equal(hubtypes.StatusActive) {
+			continue
+		}
+
+		switch v := subscription.(type) {
+		case *subscriptiontypes.NodeSubscription:
+			if v.NodeAddress == address.String() {
+				return subscription.GetID(), nil
+			}
+		case *subscriptiontypes.PlanSubscription:
+			found, _, err := queryPlanNode(ctx, v.PlanID, address)
+			if err != nil {
+				return 0, err
+			}
+			if found {
+				return subscription.GetID(), nil
+			}
+		}
+	}
+
+	return 0, nil
+}
+
+// checkSubscription validates the subscription for the node the way the chain does.
+func checkSubscription(ctx client.Context, id uint64, address hubtypes.NodeAddress) clienttypes.Checks {
+	var (
+		checks clienttypes.Checks
+		qsc    = subscriptiontypes.NewQueryServiceClient(ctx)
+	)
+
+	subscription, err := querySubscription(ctx, qsc, id)
+	if err != nil {
+		return append(checks, clienttypes.NewCheck("subscription", clienttypes.CheckStatusFail,
+			"failed to query the subscription %d: %s", id, err))
+	}
+
+	if subscription.GetStatus().Equal(hubtypes.StatusActive) {
+		checks = append(checks, clienttypes.NewCheck("subscription", clienttypes.CheckStatusOK,
+			"subscription %d of %s is active", id, subscription.GetAddress()))
+	} else {
+		checks = append(checks, clienttypes.NewCheck("subscription", clienttypes.CheckStatusFail,
+			"subscription %d is %s", id, subscription.GetStatus()))
+	}
+
+	checks = append(checks, checkQuota(ctx, qsc, subscription))
+
+	if address != nil {
+		checks = append(checks, checkSubscriptionNode(ctx, subscription, address))
+	}
+
+	if len(checks.Failed()) > 0 && address != nil {
+		other, err := findSubscription(ctx, qsc, address)
+		if err == nil && other != 0 && other != id {
+			checks = append(checks, clienttypes.NewCheck("suggestion", clienttypes.CheckStatusWarn,
+				"subscription %d of the account can be used for the node %s", other, address))
+		}
+	}
+
+	return checks
+}
+
+func checkQuota(ctx client.Context, qsc subscriptiontypes.QueryServiceClient, subscription subscriptiontypes.Subscription) clienttypes.Check {
+	id := subscription.GetID()
+
+	// Subscriptions to a node for hours are limited by the time only
+	if v, ok := subscription.(*subscriptiontypes.NodeSubscription); ok && v.Hours > 0 {
+		if !subscription.GetAddress().Equals(ctx.FromAddress) {
+			return clienttypes.NewCheck("quota", clienttypes.CheckStatusFail,
+				"subscription %d belongs to %s, not %s", id, subscription.GetAddress(), ctx.FromAddress)
+		}
+
+		left := time.Until(subscription.GetInactiveAt()).Truncate(time.Second)
+		if left <= 0 {
+			return clienttypes.NewCheck("quota", clienttypes.CheckStatusFail, "subscription %d has expired", id)
+		}
+
+		return clienttypes.NewCheck("quota", clienttypes.CheckStatusOK, "%s left of the subscription %d", left, id)
+	}
+
+	allocation, err := qsc.QueryAllocation(
+		context.Background(),
+		subscriptiontypes.NewQueryAllocationRequest(id, ctx.FromAddress),
+	)
+	if err != nil {
+		if !subscription.GetAddress().Equals(ctx.FromAddress) {
+			return clienttypes.NewCheck("quota", clienttypes.CheckStatusFail,
+				"subscription %d belongs to %s, which has not allocated any bytes to %s",
+				id, subscription.GetAddress(), ctx.FromAddress)
+		}
+
+		return clienttypes.NewCheck("quota", clienttypes.CheckStatusFail,
+			"no allocation of the subscription %d for %s: %s", id, ctx.FromAddress, err)
+	}
+
+	var (
+		granted = allocation.Allocation.GrantedBytes
+		left    = granted.Sub(allocation.Allocation.UtilisedBytes)
+	)
+
+	if !left.IsPositive() {
+		return clienttypes.NewCheck("quota", clienttypes.CheckStatusFail,
+			"allocation of the subscription %d is used up, %s of %s",
+			id, netutil.ToReadable(allocation.Allocation.UtilisedBytes.Int64(), 2), netutil.ToReadable(granted.Int64(), 2))
+	}
+
+	return clienttypes.NewCheck("quota", clienttypes.CheckStatusOK, "%s left of %s",
+		netutil.ToReadable(left.Int64(), 2), netutil.ToReadable(granted.Int64(), 2))
+}
+
+func checkSubscriptionNode(
+	ctx client.Context, subscription subscriptiontypes.Subscription, address hubtypes.NodeAddress,
+) clienttypes.Check {
+	switch v := subscription.(type) {
+	case *subscriptiontypes.NodeSubscription:
+		if v.NodeAddress != address.String() {
+			return clienttypes.NewCheck("node", clienttypes.CheckStatusFail,
+				"subscription %d is for the node %s, connect to it instead", subscription.GetID(), v.NodeAddress)
+		}
+
+		return clienttypes.NewCheck("node", clienttypes.CheckStatusOK, "subscription %d is for the node", subscription.GetID())
+	case *subscriptiontypes.PlanSubscription:
+		found, nodes, err := queryPlanNode(ctx, v.PlanID, address)
+		if err != nil {
+			return clienttypes.NewCheck("node", clienttypes.CheckStatusFail,
+				"failed to query the nodes of the plan %d: %s", v.PlanID, err)
+		}
+		if found {
+			return clienttypes.NewCheck("node", clienttypes.CheckStatusOK, "node is linked to the plan %d", v.PlanID)
+		}
+
+		message := fmt.Sprintf("node %s is not an active node of the plan %d", address, v.PlanID)
+		if len(nodes) > 0 {
+			message += fmt.Sprintf(", try %s", strings.Join(nodes, ", "))
+		}
+
+		return clienttypes.NewCheck("node", clienttypes.CheckStatusFail, "%s", message)
+	default:
+		return clienttypes.NewCheck("node", clienttypes.CheckStatusWarn,
+			"subscription %d is of an unknown type", subscription.GetID())
+	}
+}
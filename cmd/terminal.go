@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+const (
+	keyPollInterval = 100 * time.Millisecond
+)
+
+const (
+	keyRune = iota
+	keyUp
+	keyDown
+	keyPageUp
+	keyPageDown
+	keyHome
+	keyEnd
+	keyEnter
+	keyEscape
+	keyBackspace
+	keyInterrupt
+	keyUnknown
+)
+
+type key struct {
+	code int
+	r    rune
+}
+
+var (
+	escapeKeys = map[string]int{
+		"\x1b[A":  keyUp,
+		"\x1b[B":  keyDown,
+		"\x1bOA":  keyUp,
+		"\x1bOB":  keyDown,
+		"\x1b[5~": keyPageUp,
+		"\x1b[6~": keyPageDown,
+		"\x1b[H":  keyHome,
+		"\x1b[F":  keyEnd,
+		"\x1b[1~": keyHome,
+		"\x1b[4~": keyEnd,
+	}
+)
+
+// readKey reads a single key press. Escape sequences are expected to arrive
+// in a single read, as terminals write them at once.
+func readKey(r io.Reader) (key, error) {
+	buf := make([]byte, 16)
+
+	n, err := r.Read(buf)
+	if err != nil {
+		return key{}, err
+	}
+
+	s := string(buf[:n])
+	if code, ok := escapeKeys[s]; ok {
+		return key{code: code}, nil
+	}
+
+	switch s {
+	case "\x03":
+		return key{code: keyInterrupt}, nil
+	case "\r", "\n":
+		return key{code: keyEnter}, nil
+	case "\x1b":
+		return key{code: keyEscape}, nil
+	case "\x7f", "\x08":
+		return key{code: keyBackspace}, nil
+	}
+
+	if strings.HasPrefix(s, "\x1b") {
+		return key{code: keyUnknown}, nil
+	}
+
+	v, _ := utf8.DecodeRuneInString(s)
+	if v == utf8.RuneError {
+		return key{code: keyUnknown}, nil
+	}
+
+	return key{code: keyRune, r: v}, nil
+}
+
+// screen is the terminal in the raw mode, drawn on the alternate buffer so
+// that the previous contents are restored when closed.
+type screen struct {
+	fd    int
+	state *term.State
+	out   *os.File
+}
+
+func openScreen() (*screen, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil, errors.New("an interactive terminal is required")
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &screen{
+		fd:    fd,
+		state: state,
+		out:   os.Stdout,
+	}
+
+	_, _ = io.WriteString(s.out, "\x1b[?1049h\x1b[?25l")
+	return s, nil
+}
+
+// readKey waits for a key press, giving up when the context is done, so that
+// nothing is left reading the standard input.
+func (s *screen) readKey(ctx context.Context) (key, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return key{}, err
+		}
+
+		ok, err := waitForInput(s.fd, keyPollInterval)
+		if err != nil {
+			return key{}, err
+		}
+		if ok {
+			return readKey(os.Stdin)
+		}
+	}
+}
+
+func (s *screen) Close() error {
+	_, _ = io.WriteString(s.out, "\x1b[?25h\x1b[?1049l")
+	return term.Restore(s.fd, s.state)
+}
+
+func (s *screen) size() (width, height int) {
+	width, height, err := term.GetSize(int(s.out.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+
+	return width, height
+}
+
+// draw replaces the contents of the screen with the lines, cut to its width.
+func (s *screen) draw(lines []string) {
+	width, height := s.size()
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\x1b[H\x1b[2J")
+
+	for i, line := range lines {
+		if i > 0 {
+			buf.WriteString("\r\n")
+		}
+
+		buf.WriteString(truncate(line, width))
+	}
+
+	_, _ = s.out.Write(buf.Bytes())
+}
+
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(runes[:width])
+	}
+
+	return string(runes[:width-1]) + "~"
+}
+
+func pad(s string, width int) string {
+	return fmt.Sprintf("%-*s", width, truncate(s, width))
+}
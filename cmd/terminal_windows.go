@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	keyEventType = 0x0001
+)
+
+var (
+	kernel32              = windows.NewLazySystemDLL("kernel32.dll")
+	procPeekConsoleInputW = kernel32.NewProc("PeekConsoleInputW")
+	procReadConsoleInputW = kernel32.NewProc("ReadConsoleInputW")
+)
+
+// inputRecord is an INPUT_RECORD, laid out as the KEY_EVENT_RECORD.
+type inputRecord struct {
+	eventType uint16
+	_         uint16
+	keyDown   int32
+	repeat    uint16
+	keyCode   uint16
+	scanCode  uint16
+	char      uint16
+	state     uint32
+}
+
+// waitForInput reports whether the console has input to read within the
+// timeout. The console is signalled for the events which a read skips, such
+// as key releases and resizes, so these are discarded instead of reported.
+func waitForInput(fd int, timeout time.Duration) (bool, error) {
+	var (
+		handle   = windows.Handle(fd)
+		deadline = time.Now().Add(timeout)
+	)
+
+	for {
+		remaining := time.Until(deadline)
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		event, err := windows.WaitForSingleObject(handle, uint32(remaining.Milliseconds()))
+		if err != nil {
+			return false, err
+		}
+		if event != windows.WAIT_OBJECT_0 {
+			return false, nil
+		}
+
+		var (
+			record inputRecord
+			n      uint32
+		)
+
+		if err = consoleInput(procPeekConsoleInputW, handle, &record, &n); err != nil || n == 0 {
+			return false, err
+		}
+		if record.eventType == keyEventType && record.keyDown != 0 && record.char != 0 {
+			return true, nil
+		}
+		if err = consoleInput(procReadConsoleInputW, handle, &record, &n); err != nil {
+			return false, err
+		}
+	}
+}
+
+// consoleInput calls PeekConsoleInputW or ReadConsoleInputW for one record.
+func consoleInput(proc *windows.LazyProc, handle windows.Handle, record *inputRecord, n *uint32) error {
+	r, _, err := proc.Call(uintptr(handle), uintptr(unsafe.Pointer(record)), 1, uintptr(unsafe.Pointer(n)))
+	if r == 0 {
+		return err
+	}
+
+	return nil
+}
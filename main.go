@@ -14,11 +14,18 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/sentinel-official/cli-client/cmd"
+	"github.com/sentinel-official/cli-client/services/v2ray"
+	"github.com/sentinel-official/cli-client/services/wireguard"
 	"github.com/sentinel-official/cli-client/types"
 )
 
 func main() {
 	hubtypes.GetConfig().Seal()
+	types.RegisterService(
+		wireguard.Definition,
+		v2ray.Definition,
+	)
+
 	root := &cobra.Command{
 		Use:          "sentinelcli",
 		SilenceUsage: true,
@@ -54,6 +61,13 @@ func main() {
 	root.AddCommand(
 		cmd.ConnectCmd(),
 		cmd.DisconnectCmd(),
+		cmd.StatusCmd(),
+		cmd.DoctorCmd(),
+		cmd.BrowseCmd(),
+		cmd.NodesCmd(),
+		cmd.CacheCmd(),
+		cmd.PricingCmd(),
+		cmd.NetworkCmd(),
 		cmd.QueryCommand(),
 		cmd.TxCommand(),
 		keys.Commands(types.DefaultHomeDirectory),
@@ -0,0 +1,56 @@
+package v2ray
+
+import (
+	"os/exec"
+
+	"github.com/sentinel-official/cli-client/services/v2ray/types"
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	netutil "github.com/sentinel-official/cli-client/utils/net"
+	osutil "github.com/sentinel-official/cli-client/utils/os"
+)
+
+const (
+	// minMajorVersion is the first version with the run and api subcommands
+	minMajorVersion = 5
+)
+
+func preflight(opts *clienttypes.ServiceOptions) clienttypes.Checks {
+	var (
+		checks clienttypes.Checks
+		s      = NewV2Ray(&types.Config{})
+	)
+
+	path, err := exec.LookPath(s.execFile(v2ray))
+	if err != nil {
+		checks = append(checks, clienttypes.NewCheck(v2ray, clienttypes.CheckStatusFail, "not found: %s", err))
+	} else {
+		version, err := osutil.CommandVersion(path, "version")
+		major, _, ok := osutil.ParseVersion(version)
+
+		switch {
+		case err != nil || !ok:
+			checks = append(checks, clienttypes.NewCheck(v2ray, clienttypes.CheckStatusWarn, "%s, unknown version", path))
+		case major < minMajorVersion:
+			checks = append(checks, clienttypes.NewCheck(v2ray, clienttypes.CheckStatusFail,
+				"%s is not supported, version %d or later is required", version, minMajorVersion))
+		default:
+			checks = append(checks, clienttypes.NewCheck(v2ray, clienttypes.CheckStatusOK, "%s", version))
+		}
+	}
+
+	owner, owned := opts.ProxyPorts[opts.ProxyPort]
+
+	switch {
+	case owned:
+		checks = append(checks, clienttypes.NewCheck("proxy port", clienttypes.CheckStatusFail,
+			"port %d is used by the connection %s, disconnect it or choose another with --%s",
+			opts.ProxyPort, owner, clienttypes.FlagV2RayProxyPort))
+	case netutil.IsFreeTCPPort(opts.ProxyPort):
+		checks = append(checks, clienttypes.NewCheck("proxy port", clienttypes.CheckStatusOK, "port %d is free", opts.ProxyPort))
+	default:
+		checks = append(checks, clienttypes.NewCheck("proxy port", clienttypes.CheckStatusFail,
+			"port %d is already in use, choose another with --%s", opts.ProxyPort, clienttypes.FlagV2RayProxyPort))
+	}
+
+	return checks
+}
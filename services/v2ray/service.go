@@ -0,0 +1,116 @@
+package v2ray
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/go-uuid"
+
+	"github.com/sentinel-official/cli-client/services/v2ray/types"
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	netutil "github.com/sentinel-official/cli-client/utils/net"
+)
+
+var (
+	Definition = clienttypes.ServiceDefinition{
+		Type:              types.ServiceType,
+		Name:              "V2Ray",
+		DecodeConfig:      decodeConfig,
+		GenerateKey:       generateKey,
+		ParseResult:       parseResult,
+		Preflight:         preflight,
+		PlaceholderResult: []byte{0, 0, 0, 0, 0, 0, 0x01},
+	}
+)
+
+func NewService(cfg *types.Config) clienttypes.Service {
+	return clienttypes.NewServiceAdapter(NewV2Ray(cfg))
+}
+
+func decodeConfig(data []byte) (clienttypes.Service, error) {
+	var cfg types.Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	return NewService(&cfg), nil
+}
+
+func generateKey() (string, []byte, error) {
+	uid, err := uuid.GenerateRandomBytes(16)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return base64.StdEncoding.EncodeToString(append([]byte{0x01}, uid...)), uid, nil
+}
+
+func transport(v byte) string {
+	switch v {
+	case 0x01:
+		return "tcp"
+	case 0x02:
+		return "mkcp"
+	case 0x03:
+		return "websocket"
+	case 0x04:
+		return "http"
+	case 0x05:
+		return "domainsocket"
+	case 0x06:
+		return "quic"
+	case 0x07:
+		return "gun"
+	case 0x08:
+		return "grpc"
+	default:
+		return ""
+	}
+}
+
+func parseResult(result, secret []byte, opts *clienttypes.ServiceOptions) (clienttypes.Service, error) {
+	if len(result) != 7 {
+		return nil, fmt.Errorf("incorrect result size %d", len(result))
+	}
+
+	var (
+		vMessAddress   = net.IP(result[0:4])
+		vMessPort      = binary.BigEndian.Uint16(result[4:6])
+		vMessTransport = transport(result[6])
+	)
+
+	if !netutil.IsFreeTCPPort(opts.ProxyPort) {
+		return nil, fmt.Errorf("proxy port %d is already in use", opts.ProxyPort)
+	}
+
+	uid, err := uuid.FormatUUID(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	apiPort, err := netutil.GetFreeTCPPort()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &types.Config{
+		Name: opts.Name,
+		API: &types.APIConfig{
+			Port: apiPort,
+		},
+		Proxy: &types.ProxyConfig{
+			Port: opts.ProxyPort,
+		},
+		VMess: &types.VMessConfig{
+			Address:   vMessAddress.String(),
+			ID:        uid,
+			Port:      vMessPort,
+			Transport: vMessTransport,
+		},
+	}
+
+	return NewService(cfg), nil
+}
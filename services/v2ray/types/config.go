@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 	"text/template"
+	"time"
 )
 
 var (
@@ -115,7 +116,7 @@ type APIConfig struct {
 }
 
 type ProxyConfig struct {
-	Port uint16 `json:"-"`
+	Port uint16 `json:"port"`
 }
 
 type VMessConfig struct {
@@ -126,22 +127,33 @@ type VMessConfig struct {
 }
 
 type Config struct {
+	Name  string       `json:"name"`
 	PID   int32        `json:"pid"`
+	UpAt  time.Time    `json:"up_at"`
 	API   *APIConfig   `json:"api"`
-	Proxy *ProxyConfig `json:"-"`
+	Proxy *ProxyConfig `json:"proxy"`
 	VMess *VMessConfig `json:"-"`
 }
 
-func (c *Config) WriteToFile(path string) error {
+func (c *Config) Render() ([]byte, error) {
 	t, err := template.New("config_v2ray_json").Parse(configTemplate)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var buf bytes.Buffer
 	if err = t.Execute(&buf, c); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (c *Config) WriteToFile(path string) error {
+	buf, err := c.Render()
+	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(path, buf.Bytes(), 0600)
+	return os.WriteFile(path, buf, 0600)
 }
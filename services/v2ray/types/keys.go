@@ -2,4 +2,6 @@ package types
 
 const (
 	DefaultConfigFileName = "v2ray_config.json"
+	ConfigFileNameFormat  = "v2ray_config_%s.json"
+	ServiceType           = 2
 )
@@ -1,9 +1,17 @@
 package v2ray
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/shirou/gopsutil/v3/process"
@@ -14,7 +22,11 @@ import (
 )
 
 var (
-	_ clienttypes.Service = (*V2Ray)(nil)
+	_ clienttypes.LegacyService  = (*V2Ray)(nil)
+	_ clienttypes.HealthChecker  = (*V2Ray)(nil)
+	_ clienttypes.StatsReporter  = (*V2Ray)(nil)
+	_ clienttypes.ConfigRenderer = (*V2Ray)(nil)
+	_ clienttypes.Proxied        = (*V2Ray)(nil)
 )
 
 type V2Ray struct {
@@ -27,9 +39,16 @@ func NewV2Ray(cfg *types.Config) *V2Ray {
 	}
 }
 
-func (s *V2Ray) home() string           { return viper.GetString(flags.FlagHome) }
-func (s *V2Ray) configFilePath() string { return filepath.Join(s.home(), types.DefaultConfigFileName) }
-func (s *V2Ray) pid() int32             { return s.cfg.PID }
+func (s *V2Ray) home() string { return viper.GetString(flags.FlagHome) }
+func (s *V2Ray) pid() int32   { return s.cfg.PID }
+
+func (s *V2Ray) configFilePath() string {
+	if s.cfg.Name == "" {
+		return filepath.Join(s.home(), types.DefaultConfigFileName)
+	}
+
+	return filepath.Join(s.home(), fmt.Sprintf(types.ConfigFileNameFormat, s.cfg.Name))
+}
 
 func (s *V2Ray) Info() []byte {
 	buf, err := json.Marshal(s.cfg)
@@ -40,6 +59,22 @@ func (s *V2Ray) Info() []byte {
 	return buf
 }
 
+func (s *V2Ray) RenderConfig() (string, error) {
+	buf, err := s.cfg.Render()
+	if err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+}
+
+func (s *V2Ray) ProxyURL() *url.URL {
+	return &url.URL{
+		Scheme: "socks5",
+		Host:   net.JoinHostPort("127.0.0.1", strconv.Itoa(int(s.cfg.Proxy.Port))),
+	}
+}
+
 func (s *V2Ray) PreUp() error {
 	cfgFilePath := s.configFilePath()
 	return s.cfg.WriteToFile(cfgFilePath)
@@ -78,7 +113,11 @@ func (s *V2Ray) IsUp() bool {
 	return true
 }
 
-func (s *V2Ray) PostUp() error  { return nil }
+func (s *V2Ray) PostUp() error {
+	s.cfg.UpAt = time.Now()
+	return nil
+}
+
 func (s *V2Ray) PreDown() error { return nil }
 
 func (s *V2Ray) Down() error {
@@ -100,5 +139,107 @@ func (s *V2Ray) PostDown() error {
 }
 
 func (s *V2Ray) Transfer() (int64, int64, error) {
-	return 0, 0, nil
+	stats, err := s.queryStats(context.Background())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return stats["outbound>>>vmess>>>traffic>>>downlink"], stats["outbound>>>vmess>>>traffic>>>uplink"], nil
+}
+
+func (s *V2Ray) queryStats(ctx context.Context) (map[string]int64, error) {
+	cmd := exec.CommandContext(
+		ctx,
+		s.execFile(v2ray),
+		"api", "stats",
+		fmt.Sprintf("--server=127.0.0.1:%d", s.cfg.API.Port),
+		"-json",
+	)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var res struct {
+		Stat []struct {
+			Name  string          `json:"name"`
+			Value json.RawMessage `json:"value"`
+		} `json:"stat"`
+	}
+
+	if err = json.Unmarshal(output, &res); err != nil {
+		return nil, err
+	}
+
+	items := make(map[string]int64)
+	for _, item := range res.Stat {
+		if len(item.Value) == 0 {
+			continue
+		}
+
+		v, err := strconv.ParseInt(strings.Trim(string(item.Value), `"`), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		items[item.Name] = v
+	}
+
+	return items, nil
+}
+
+func (s *V2Ray) isProxyListening(ctx context.Context) bool {
+	if s.cfg.Proxy == nil {
+		return false
+	}
+
+	var (
+		dialer  = net.Dialer{Timeout: 5 * time.Second}
+		address = net.JoinHostPort("127.0.0.1", strconv.Itoa(int(s.cfg.Proxy.Port)))
+	)
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return false
+	}
+
+	_ = conn.Close()
+	return true
+}
+
+func (s *V2Ray) Health(ctx context.Context) (*clienttypes.Health, error) {
+	if !s.IsUp() {
+		return &clienttypes.Health{State: clienttypes.HealthStateDown}, nil
+	}
+
+	health := &clienttypes.Health{
+		State:            clienttypes.HealthStateDegraded,
+		ProcessAlive:     true,
+		InterfacePresent: s.isProxyListening(ctx),
+	}
+
+	if health.InterfacePresent {
+		health.State = clienttypes.HealthStateUp
+	}
+
+	return health, nil
+}
+
+func (s *V2Ray) Stats() (*clienttypes.Stats, error) {
+	download, upload, err := s.Transfer()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &clienttypes.Stats{
+		Upload:   upload,
+		Download: download,
+	}
+
+	if !s.cfg.UpAt.IsZero() {
+		stats.Uptime = time.Since(s.cfg.UpAt)
+	}
+
+	return stats, nil
 }
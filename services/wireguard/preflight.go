@@ -0,0 +1,45 @@
+package wireguard
+
+import (
+	"os/exec"
+
+	"github.com/sentinel-official/cli-client/services/wireguard/types"
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	osutil "github.com/sentinel-official/cli-client/utils/os"
+)
+
+func preflight(_ *clienttypes.ServiceOptions) clienttypes.Checks {
+	var (
+		checks clienttypes.Checks
+		s      = NewWireGuard(&types.Config{})
+	)
+
+	for _, name := range binaries {
+		path, err := exec.LookPath(s.execFile(name))
+		if err != nil {
+			checks = append(checks, clienttypes.NewCheck(name, clienttypes.CheckStatusFail, "not found: %s", err))
+			continue
+		}
+		if name != "wg" {
+			checks = append(checks, clienttypes.NewCheck(name, clienttypes.CheckStatusOK, "%s", path))
+			continue
+		}
+
+		version, err := osutil.CommandVersion(path, "--version")
+		if err != nil {
+			checks = append(checks, clienttypes.NewCheck(name, clienttypes.CheckStatusWarn, "%s, unknown version: %s", path, err))
+			continue
+		}
+
+		checks = append(checks, clienttypes.NewCheck(name, clienttypes.CheckStatusOK, "%s", version))
+	}
+
+	if osutil.IsPrivileged() {
+		checks = append(checks, clienttypes.NewCheck("privileges", clienttypes.CheckStatusOK, "running with the administrative privileges"))
+	} else {
+		checks = append(checks, clienttypes.NewCheck("privileges", clienttypes.CheckStatusFail,
+			"WireGuard requires the administrative privileges, run as root or an Administrator"))
+	}
+
+	return checks
+}
@@ -0,0 +1,127 @@
+package wireguard
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/viper"
+
+	"github.com/sentinel-official/cli-client/services/wireguard/types"
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	netutil "github.com/sentinel-official/cli-client/utils/net"
+)
+
+var (
+	Definition = clienttypes.ServiceDefinition{
+		Type:              types.ServiceType,
+		Name:              "WireGuard",
+		FullTunnel:        true,
+		DecodeConfig:      decodeConfig,
+		GenerateKey:       generateKey,
+		ParseResult:       parseResult,
+		Preflight:         preflight,
+		PlaceholderResult: make([]byte, 58),
+	}
+)
+
+func NewService(cfg *types.Config) clienttypes.Service {
+	return clienttypes.NewServiceAdapter(NewWireGuard(cfg))
+}
+
+func decodeConfig(data []byte) (clienttypes.Service, error) {
+	var cfg types.Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+
+	return NewService(&cfg), nil
+}
+
+func generateKey() (string, []byte, error) {
+	key, err := types.NewPrivateKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	return key.Public().String(), key[:], nil
+}
+
+// freeInterface returns an interface name used by neither a connection nor the system.
+func freeInterface() (string, error) {
+	home := viper.GetString(flags.FlagHome)
+	for i := types.MaxInterfaceIndex; i >= 0; i-- {
+		name := fmt.Sprintf("%s%d", types.InterfacePrefix, i)
+		if _, err := os.Stat(filepath.Join(home, fmt.Sprintf("%s.conf", name))); err == nil {
+			continue
+		}
+		if _, err := net.InterfaceByName(name); err == nil {
+			continue
+		}
+
+		return name, nil
+	}
+
+	return "", errors.New("no free wireguard interface available")
+}
+
+func parseResult(result, secret []byte, opts *clienttypes.ServiceOptions) (clienttypes.Service, error) {
+	if len(result) != 58 {
+		return nil, fmt.Errorf("incorrect result size %d", len(result))
+	}
+
+	var (
+		ipv4Address         = net.IP(result[0:4])
+		ipv6Address         = net.IP(result[4:20])
+		endpointHost        = net.IP(result[20:24])
+		endpointPort        = binary.BigEndian.Uint16(result[24:26])
+		endpointWGPublicKey = types.NewKey(result[26:58])
+	)
+
+	name, err := freeInterface()
+	if err != nil {
+		return nil, err
+	}
+
+	listenPort, err := netutil.GetFreeUDPPort()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &types.Config{
+		Name: name,
+		Interface: types.Interface{
+			Addresses: []types.IPNet{
+				{IP: ipv4Address, Net: 32},
+				{IP: ipv6Address, Net: 128},
+			},
+			ListenPort: listenPort,
+			PrivateKey: *types.NewKey(secret),
+			DNS: append(
+				[]net.IP{net.ParseIP("10.8.0.1")},
+				opts.Resolvers...,
+			),
+		},
+		Peers: []types.Peer{
+			{
+				PublicKey: *endpointWGPublicKey,
+				AllowedIPs: []types.IPNet{
+					{IP: net.ParseIP("0.0.0.0")},
+					{IP: net.ParseIP("::")},
+				},
+				Endpoint: types.Endpoint{
+					Host: endpointHost.String(),
+					Port: endpointPort,
+				},
+				PersistentKeepalive: 15,
+			},
+		},
+	}
+
+	return NewService(cfg), nil
+}
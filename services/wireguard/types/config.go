@@ -5,10 +5,12 @@ import (
 	"net"
 	"os"
 	"strings"
+	"time"
 )
 
 type Config struct {
 	Name      string    `json:"name"`
+	UpAt      time.Time `json:"up_at"`
 	Interface Interface `json:"-"`
 	Peers     []Peer    `json:"-"`
 }
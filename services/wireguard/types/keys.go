@@ -1,5 +1,7 @@
 package types
 
 const (
-	DefaultInterface = "wg99"
+	InterfacePrefix   = "wg"
+	MaxInterfaceIndex = 99
+	ServiceType       = 1
 )
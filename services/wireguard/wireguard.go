@@ -1,6 +1,7 @@
 package wireguard
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/spf13/viper"
@@ -16,8 +18,15 @@ import (
 	clienttypes "github.com/sentinel-official/cli-client/types"
 )
 
+const (
+	handshakeTimeout = 3 * time.Minute
+)
+
 var (
-	_ clienttypes.Service = (*WireGuard)(nil)
+	_ clienttypes.LegacyService  = (*WireGuard)(nil)
+	_ clienttypes.HealthChecker  = (*WireGuard)(nil)
+	_ clienttypes.StatsReporter  = (*WireGuard)(nil)
+	_ clienttypes.ConfigRenderer = (*WireGuard)(nil)
 )
 
 type WireGuard struct {
@@ -45,6 +54,10 @@ func (s *WireGuard) Info() []byte {
 	return buf
 }
 
+func (s *WireGuard) RenderConfig() (string, error) {
+	return s.cfg.ToWgQuick(), nil
+}
+
 func (s *WireGuard) IsUp() bool {
 	iFace, err := s.realInterface()
 	if err != nil {
@@ -75,7 +88,11 @@ func (s *WireGuard) PreUp() error {
 	return s.cfg.WriteToFile(cfgFilePath)
 }
 
-func (s *WireGuard) PostUp() error  { return nil }
+func (s *WireGuard) PostUp() error {
+	s.cfg.UpAt = time.Now()
+	return nil
+}
+
 func (s *WireGuard) PreDown() error { return nil }
 
 func (s *WireGuard) PostDown() error {
@@ -128,3 +145,102 @@ func (s *WireGuard) Transfer() (u int64, d int64, err error) {
 
 	return 0, 0, nil
 }
+
+func (s *WireGuard) latestHandshake(ctx context.Context) (time.Time, error) {
+	iFace, err := s.realInterface()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	cmd := exec.CommandContext(
+		ctx,
+		s.execFile("wg"),
+		strings.Split(
+			fmt.Sprintf("show %s latest-handshakes", iFace),
+			" ",
+		)...,
+	)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	lines := strings.Split(string(output), "\n")
+	for _, line := range lines {
+		columns := strings.Split(line, "\t")
+		if len(columns) != 2 {
+			continue
+		}
+
+		v, err := strconv.ParseInt(columns[1], 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if v == 0 {
+			return time.Time{}, nil
+		}
+
+		return time.Unix(v, 0), nil
+	}
+
+	return time.Time{}, nil
+}
+
+func (s *WireGuard) Health(ctx context.Context) (*clienttypes.Health, error) {
+	if !s.IsUp() {
+		return &clienttypes.Health{State: clienttypes.HealthStateDown}, nil
+	}
+
+	lastHandshake, err := s.latestHandshake(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &clienttypes.Health{
+		State:            clienttypes.HealthStateDegraded,
+		ProcessAlive:     true,
+		InterfacePresent: true,
+		LastHandshake:    lastHandshake,
+	}
+
+	if !lastHandshake.IsZero() {
+		health.HandshakeAge = time.Since(lastHandshake)
+		if health.HandshakeAge < handshakeTimeout {
+			health.State = clienttypes.HealthStateUp
+		}
+	}
+
+	return health, nil
+}
+
+func (s *WireGuard) Stats() (*clienttypes.Stats, error) {
+	download, upload, err := s.Transfer()
+	if err != nil {
+		return nil, err
+	}
+
+	downloadPackets, uploadPackets, err := s.packets()
+	if err != nil {
+		return nil, err
+	}
+
+	lastHandshake, err := s.latestHandshake(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &clienttypes.Stats{
+		Upload:          upload,
+		Download:        download,
+		UploadPackets:   uploadPackets,
+		DownloadPackets: downloadPackets,
+		LastHandshake:   lastHandshake,
+	}
+
+	if !s.cfg.UpAt.IsZero() {
+		stats.Uptime = time.Since(s.cfg.UpAt)
+	}
+
+	return stats, nil
+}
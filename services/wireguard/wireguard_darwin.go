@@ -8,6 +8,10 @@ import (
 	"strings"
 )
 
+var (
+	binaries = []string{"wg", "wg-quick"}
+)
+
 func (s *WireGuard) realInterface() (string, error) {
 	nameFile, err := os.Open(fmt.Sprintf("/var/run/wireguard/%s.name", s.cfg.Name))
 	if err != nil {
@@ -55,3 +59,7 @@ func (s *WireGuard) Down() error {
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
+
+func (s *WireGuard) packets() (int64, int64, error) {
+	return 0, 0, nil
+}
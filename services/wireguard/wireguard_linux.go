@@ -4,9 +4,15 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 )
 
+var (
+	binaries = []string{"wg", "wg-quick"}
+)
+
 func (s *WireGuard) realInterface() (string, error) {
 	return s.cfg.Name, nil
 }
@@ -42,3 +48,28 @@ func (s *WireGuard) Down() error {
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
+
+func (s *WireGuard) packets() (rx int64, tx int64, err error) {
+	iFace, err := s.realInterface()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	read := func(name string) (int64, error) {
+		data, err := os.ReadFile(filepath.Join("/sys/class/net", iFace, "statistics", name))
+		if err != nil {
+			return 0, err
+		}
+
+		return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	}
+
+	if rx, err = read("rx_packets"); err != nil {
+		return 0, 0, err
+	}
+	if tx, err = read("tx_packets"); err != nil {
+		return 0, 0, err
+	}
+
+	return rx, tx, nil
+}
@@ -6,6 +6,10 @@ import (
 	"path/filepath"
 )
 
+var (
+	binaries = []string{"wg", "wireguard"}
+)
+
 func (s *WireGuard) realInterface() (string, error) {
 	return s.cfg.Name, nil
 }
@@ -42,3 +46,7 @@ func (s *WireGuard) Down() error {
 	cmd.Stderr = os.Stderr
 	return cmd.Run()
 }
+
+func (s *WireGuard) packets() (int64, int64, error) {
+	return 0, 0, nil
+}
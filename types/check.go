@@ -0,0 +1,38 @@
+package types
+
+import (
+	"fmt"
+)
+
+const (
+	CheckStatusOK   = "ok"
+	CheckStatusWarn = "warn"
+	CheckStatusFail = "fail"
+)
+
+type Check struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+func NewCheck(name, status, format string, args ...interface{}) Check {
+	return Check{
+		Name:    name,
+		Status:  status,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+type Checks []Check
+
+func (c Checks) Failed() Checks {
+	var items Checks
+	for _, item := range c {
+		if item.Status == CheckStatusFail {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
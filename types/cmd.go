@@ -1,7 +1,29 @@
 package types
 
 const (
-	FlagTimeout        = "timeout"
-	FlagResolver       = "resolver"
-	FlagV2RayProxyPort = "v2ray.proxy-port"
+	FlagAll                 = "all"
+	FlagCacheTTL            = "cache.ttl"
+	FlagDryRun              = "dry-run"
+	FlagEndSession          = "end-session"
+	FlagForceReset          = "force-reset"
+	FlagHTTPDialTimeout     = "http.dial-timeout"
+	FlagHTTPProxy           = "http.proxy"
+	FlagHTTPResponseTimeout = "http.response-timeout"
+	FlagHTTPRetries         = "http.retries"
+	FlagHTTPTLSTimeout      = "http.tls-timeout"
+	FlagMaxBytes            = "max-bytes"
+	FlagMaxDuration         = "max-duration"
+	FlagMaxItems            = "max-items"
+	FlagName                = "name"
+	FlagPageInterval        = "page-interval"
+	FlagResume              = "resume"
+	FlagReuseSession        = "reuse-session"
+	FlagSkipChecks          = "skip-checks"
+	FlagTimeout             = "timeout"
+	FlagTLSAcceptChanged    = "tls.accept-changed"
+	FlagTLSStrict           = "tls.strict"
+	FlagResolver            = "resolver"
+	FlagV2RayProxyPort      = "v2ray.proxy-port"
+	FlagWait                = "wait"
+	FlagWarnThresholds      = "warn-thresholds"
 )
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"path/filepath"
+	"time"
+)
+
+const (
+	BlockedFileName   = "blocked.json"
+	FavoritesFileName = "favorites.json"
+)
+
+type NodeListItem struct {
+	Address string    `json:"address"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// NodeList is a set of node addresses kept in a file in the home directory.
+type NodeList struct {
+	*JSONStore[NodeListItem]
+}
+
+func NewNodeList(path string) *NodeList {
+	return &NodeList{
+		JSONStore: NewJSONStore(path, func(v NodeListItem) string { return v.Address }),
+	}
+}
+
+func NewFavorites(home string) *NodeList {
+	return NewNodeList(filepath.Join(home, FavoritesFileName))
+}
+
+func NewBlocked(home string) *NodeList {
+	return NewNodeList(filepath.Join(home, BlockedFileName))
+}
+
+func (l *NodeList) Has(address string) (ok bool) {
+	l.View(func(items map[string]NodeListItem) {
+		_, ok = items[address]
+	})
+
+	return ok
+}
+
+func (l *NodeList) Add(address string) (ok bool) {
+	l.Update(func(items map[string]NodeListItem) bool {
+		if _, found := items[address]; found {
+			return false
+		}
+
+		items[address] = NodeListItem{
+			Address: address,
+			AddedAt: time.Now().UTC(),
+		}
+		ok = true
+
+		return true
+	})
+
+	return ok
+}
+
+func (l *NodeList) Remove(address string) (ok bool) {
+	l.Update(func(items map[string]NodeListItem) bool {
+		if _, ok = items[address]; ok {
+			delete(items, address)
+		}
+
+		return ok
+	})
+
+	return ok
+}
+
+// Toggle adds the address to the list, or removes it if already there, and
+// returns whether it is in the list now.
+func (l *NodeList) Toggle(address string) bool {
+	if l.Remove(address) {
+		return false
+	}
+
+	return l.Add(address)
+}
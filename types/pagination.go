@@ -0,0 +1,98 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/spf13/cobra"
+)
+
+// PaginateOptions controls fetching all the pages of a list query.
+type PaginateOptions struct {
+	All      bool
+	Interval time.Duration
+	MaxItems int
+}
+
+// DefaultPaginateOptions returns the options for fetching all the pages.
+func DefaultPaginateOptions() PaginateOptions {
+	return PaginateOptions{
+		All:      true,
+		Interval: 200 * time.Millisecond,
+		MaxItems: 10000,
+	}
+}
+
+func AddPaginateFlagsToCmd(cmd *cobra.Command) {
+	opts := DefaultPaginateOptions()
+
+	cmd.Flags().Bool(FlagAll, false, "fetch all the pages, following the next key until exhausted")
+	cmd.Flags().Duration(FlagPageInterval, opts.Interval, "time waited between the page requests with --all")
+	cmd.Flags().Int(FlagMaxItems, opts.MaxItems, "maximum number of the items fetched with --all")
+}
+
+func NewPaginateOptionsFromCmd(cmd *cobra.Command) (opts PaginateOptions, err error) {
+	opts.All, err = cmd.Flags().GetBool(FlagAll)
+	if err != nil {
+		return opts, err
+	}
+
+	opts.Interval, err = cmd.Flags().GetDuration(FlagPageInterval)
+	if err != nil {
+		return opts, err
+	}
+
+	opts.MaxItems, err = cmd.Flags().GetInt(FlagMaxItems)
+	if err != nil {
+		return opts, err
+	}
+	if opts.MaxItems < 1 {
+		return opts, fmt.Errorf("invalid maximum number of the items %d", opts.MaxItems)
+	}
+
+	return opts, nil
+}
+
+// Paginate calls the query with the page request, and with opts.All for the
+// next pages as well, returning the items of all of them.
+func Paginate[T any](
+	ctx context.Context, pagination *query.PageRequest, opts PaginateOptions,
+	fn func(pagination *query.PageRequest) ([]T, *query.PageResponse, error),
+) ([]T, error) {
+	if pagination == nil {
+		pagination = &query.PageRequest{}
+	}
+
+	var items []T
+	for {
+		page, res, err := fn(pagination)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+		if !opts.All {
+			return items, nil
+		}
+		if len(items) >= opts.MaxItems {
+			return items[:opts.MaxItems], nil
+		}
+		if res == nil || len(res.NextKey) == 0 {
+			return items, nil
+		}
+
+		pagination = &query.PageRequest{
+			Key:     res.NextKey,
+			Limit:   pagination.Limit,
+			Reverse: pagination.Reverse,
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(opts.Interval):
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+// pages returns a query of the pages, whose keys are their indexes.
+func pages(items ...[]int) func(*query.PageRequest) ([]int, *query.PageResponse, error) {
+	return func(pagination *query.PageRequest) ([]int, *query.PageResponse, error) {
+		i, _ := strconv.Atoi(string(pagination.Key))
+
+		res := &query.PageResponse{}
+		if i+1 < len(items) {
+			res.NextKey = []byte(strconv.Itoa(i + 1))
+		}
+
+		return items[i], res, nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	fn := pages([]int{1, 2}, []int{3, 4}, []int{5})
+
+	got, err := Paginate(context.Background(), nil, PaginateOptions{MaxItems: 10}, fn)
+	if err != nil || !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("first page: %v, %v", got, err)
+	}
+
+	got, err = Paginate(context.Background(), nil, PaginateOptions{All: true, MaxItems: 10}, fn)
+	if err != nil || !reflect.DeepEqual(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("all pages: %v, %v", got, err)
+	}
+
+	got, err = Paginate(context.Background(), nil, PaginateOptions{All: true, MaxItems: 3}, fn)
+	if err != nil || !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("capped: %v, %v", got, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err = Paginate(ctx, nil, DefaultPaginateOptions(), fn); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled: got %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,118 @@
+package types
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+)
+
+const (
+	PinsFileName = "pins.json"
+)
+
+type Pin struct {
+	Address     string    `json:"address"`
+	Fingerprint string    `json:"fingerprint"`
+	PinnedAt    time.Time `json:"pinned_at"`
+}
+
+func Fingerprint(cert []byte) string {
+	sum := sha256.Sum256(cert)
+	return hex.EncodeToString(sum[:])
+}
+
+type PinStore struct {
+	*JSONStore[Pin]
+	warn io.Writer
+}
+
+func NewPinStore(home string, warn io.Writer) *PinStore {
+	return &PinStore{
+		JSONStore: NewJSONStore(filepath.Join(home, PinsFileName), func(v Pin) string { return v.Address }),
+		warn:      warn,
+	}
+}
+
+func (s *PinStore) Get(address string) (item Pin, ok bool) {
+	s.View(func(items map[string]Pin) {
+		item, ok = items[address]
+	})
+
+	return item, ok
+}
+
+func (s *PinStore) Set(address, fingerprint string) {
+	s.Update(func(items map[string]Pin) bool {
+		items[address] = Pin{
+			Address:     address,
+			Fingerprint: fingerprint,
+			PinnedAt:    time.Now().UTC(),
+		}
+
+		return true
+	})
+}
+
+func (s *PinStore) Delete(address string) (ok bool) {
+	s.Update(func(items map[string]Pin) bool {
+		if _, ok = items[address]; ok {
+			delete(items, address)
+		}
+
+		return ok
+	})
+
+	return ok
+}
+
+// Verify checks the certificate against the pin, pinning it on the first use.
+func (s *PinStore) Verify(address string, cert []byte, strict bool) error {
+	fingerprint := Fingerprint(cert)
+
+	item, ok := s.Get(address)
+	if !ok {
+		s.Set(address, fingerprint)
+		return nil
+	}
+	if item.Fingerprint == fingerprint {
+		return nil
+	}
+
+	if strict {
+		return fmt.Errorf("certificate of node %s does not match the pinned fingerprint %s, got %s; "+
+			"if the change is expected, pin the new certificate with nodes trust", address, item.Fingerprint, fingerprint)
+	}
+
+	if s.warn != nil {
+		_, _ = fmt.Fprintf(s.warn, "WARNING: certificate of node %s does not match the pinned fingerprint %s, got %s\n",
+			address, item.Fingerprint, fingerprint)
+	}
+
+	return nil
+}
+
+// TLSConfig checks the self-signed certificate of the node against its pin.
+func (s *PinStore) TLSConfig(address string, strict bool) *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return errors.New("node did not present a certificate")
+			}
+
+			return s.Verify(address, rawCerts[0], strict)
+		},
+	}
+}
+
+func (s *PinStore) TLSConfigFunc(strict bool) func(address string) *tls.Config {
+	return func(address string) *tls.Config {
+		return s.TLSConfig(address, strict)
+	}
+}
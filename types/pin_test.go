@@ -0,0 +1,31 @@
+package types
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPinStoreVerify(t *testing.T) {
+	var (
+		warn bytes.Buffer
+		s    = NewPinStore(t.TempDir(), &warn)
+	)
+
+	if err := s.Verify("node", []byte("pinned"), true); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+
+	err := s.Verify("node", []byte("changed"), true)
+	if err == nil || !strings.Contains(err.Error(), "nodes trust") {
+		t.Errorf("changed in the strict mode: got %v, want an error with a hint of nodes trust", err)
+	}
+
+	if err = s.Verify("node", []byte("changed"), false); err != nil || warn.Len() == 0 {
+		t.Errorf("changed: got %v and warning %q, want only a warning", err, warn.String())
+	}
+
+	if item, _ := s.Get("node"); item.Fingerprint != Fingerprint([]byte("pinned")) {
+		t.Errorf("pin = %v, want the one of the first use", item)
+	}
+}
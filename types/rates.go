@@ -0,0 +1,72 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Rates are the values of the denoms in a common unit, e.g. {"udvpn": 0.0000012}.
+type Rates map[string]float64
+
+func LoadRates(path string) (Rates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rates Rates
+	if err = json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("invalid rates file %s: %w", path, err)
+	}
+
+	for denom, rate := range rates {
+		if rate <= 0 {
+			return nil, fmt.Errorf("invalid rate %v of denom %s in %s", rate, denom, path)
+		}
+	}
+
+	return rates, nil
+}
+
+// Convert returns the amount of the denom from in the denom to, and false if
+// either of them has no rate.
+func (r Rates) Convert(amount float64, from, to string) (float64, bool) {
+	if from == to {
+		return amount, true
+	}
+
+	x, ok := r[from]
+	if !ok {
+		return 0, false
+	}
+
+	y, ok := r[to]
+	if !ok {
+		return 0, false
+	}
+
+	return amount * x / y, true
+}
+
+// Lowest returns the lowest of the prices converted to the denom, along with
+// the denom it was paid in, and false if none of them can be converted.
+func (r Rates) Lowest(prices Coins, denom string) (float64, string, bool) {
+	var (
+		lowest float64
+		from   string
+		found  bool
+	)
+
+	for _, item := range prices {
+		v, ok := r.Convert(float64(item.Value), item.Denom, denom)
+		if !ok {
+			continue
+		}
+		if !found || v < lowest {
+			lowest, from, found = v, item.Denom, true
+		}
+	}
+
+	return lowest, from, found
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadRates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	if err := os.WriteFile(path, []byte(`{"udvpn": 0.5, "uatom": 0}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadRates(path); err == nil {
+		t.Error("LoadRates() accepted a zero rate")
+	}
+}
+
+func TestRatesLowest(t *testing.T) {
+	rates := Rates{"udvpn": 1, "uatom": 8}
+
+	tests := []struct {
+		name      string
+		rates     Rates
+		prices    Coins
+		want      float64
+		wantDenom string
+		wantOK    bool
+	}{
+		{"converted is lower", rates, Coins{{Denom: "udvpn", Value: 100}, {Denom: "uatom", Value: 10}}, 80, "uatom", true},
+		{"unknown denom skipped", rates, Coins{{Denom: "uosmo", Value: 1}, {Denom: "udvpn", Value: 100}}, 100, "udvpn", true},
+		{"no rates", nil, Coins{{Denom: "uatom", Value: 10}}, 0, "", false},
+	}
+
+	for _, tc := range tests {
+		got, denom, ok := tc.rates.Lowest(tc.prices, "udvpn")
+		if got != tc.want || denom != tc.wantDenom || ok != tc.wantOK {
+			t.Errorf("%s: Lowest() = %v, %s, %t, want %v, %s, %t", tc.name, got, denom, ok, tc.want, tc.wantDenom, tc.wantOK)
+		}
+	}
+}
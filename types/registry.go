@@ -0,0 +1,90 @@
+package types
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+type (
+	ServiceConfigDecoder func(data []byte) (Service, error)
+	ServiceKeyGenerator  func() (key string, secret []byte, err error)
+	ServiceResultParser  func(result, secret []byte, opts *ServiceOptions) (Service, error)
+	ServicePreflight     func(opts *ServiceOptions) Checks
+)
+
+// ServiceOptions are the options of bringing up the service of the connection
+// Name. ProxyPorts are the proxy ports held by the other connections, by name.
+type ServiceOptions struct {
+	Name       string
+	Resolvers  []net.IP
+	ProxyPort  uint16
+	ProxyPorts map[uint16]string
+}
+
+// ServiceDefinition describes a service type; FullTunnel routes all the traffic.
+type ServiceDefinition struct {
+	Type              uint64
+	Name              string
+	FullTunnel        bool
+	DecodeConfig      ServiceConfigDecoder
+	GenerateKey       ServiceKeyGenerator
+	ParseResult       ServiceResultParser
+	PlaceholderResult []byte
+	Preflight         ServicePreflight
+}
+
+var (
+	services = map[uint64]ServiceDefinition{}
+)
+
+func RegisterService(items ...ServiceDefinition) {
+	for _, item := range items {
+		if _, ok := services[item.Type]; ok {
+			panic(fmt.Errorf("service type %d is already registered", item.Type))
+		}
+
+		services[item.Type] = item
+	}
+}
+
+func GetService(t uint64) (ServiceDefinition, error) {
+	item, ok := services[t]
+	if !ok {
+		return item, fmt.Errorf("invalid node type %d", t)
+	}
+
+	return item, nil
+}
+
+func GetServiceByName(name string) (ServiceDefinition, error) {
+	for _, item := range services {
+		if strings.EqualFold(item.Name, name) {
+			return item, nil
+		}
+	}
+
+	return ServiceDefinition{}, fmt.Errorf("invalid node type %s", name)
+}
+
+func Services() []ServiceDefinition {
+	items := make([]ServiceDefinition, 0, len(services))
+	for _, item := range services {
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Type < items[j].Type
+	})
+
+	return items
+}
+
+func ServiceName(t uint64) string {
+	if item, ok := services[t]; ok {
+		return item.Name
+	}
+
+	return ""
+}
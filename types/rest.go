@@ -19,3 +19,7 @@ func NewError(module string, code int, message string) *Error {
 		Module:  module,
 	}
 }
+
+func (e *Error) Error() string {
+	return e.Message
+}
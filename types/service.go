@@ -1,11 +1,59 @@
 package types
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
 	"os"
+	"time"
+
+	osutil "github.com/sentinel-official/cli-client/utils/os"
+)
+
+const (
+	StatusVersion = 1
+)
+
+var (
+	ErrorCorruptStatus     = errors.New("corrupt status")
+	ErrorUnsupportedStatus = errors.New("unsupported status version")
 )
 
+const (
+	HealthStateUp       = "up"
+	HealthStateDegraded = "degraded"
+	HealthStateDown     = "down"
+)
+
+type Health struct {
+	State            string        `json:"state"`
+	ProcessAlive     bool          `json:"process_alive"`
+	InterfacePresent bool          `json:"interface_present"`
+	LastHandshake    time.Time     `json:"last_handshake"`
+	HandshakeAge     time.Duration `json:"handshake_age"`
+}
+
+type Stats struct {
+	Upload          int64         `json:"upload"`
+	Download        int64         `json:"download"`
+	UploadPackets   int64         `json:"upload_packets"`
+	DownloadPackets int64         `json:"download_packets"`
+	LastHandshake   time.Time     `json:"last_handshake"`
+	Uptime          time.Duration `json:"uptime"`
+}
+
 type Service interface {
+	Info() []byte
+	IsUp() bool
+	Up(ctx context.Context) error
+	Down(ctx context.Context) error
+	Health(ctx context.Context) (*Health, error)
+	Stats() (*Stats, error)
+}
+
+type LegacyService interface {
 	Info() []byte
 	PreUp() error
 	IsUp() bool
@@ -17,38 +65,161 @@ type Service interface {
 	Transfer() (int64, int64, error)
 }
 
+type HealthChecker interface {
+	Health(ctx context.Context) (*Health, error)
+}
+
+type StatsReporter interface {
+	Stats() (*Stats, error)
+}
+
+// ConfigRenderer is implemented by the services which can show the config
+// they write to the disk when brought up.
+type ConfigRenderer interface {
+	RenderConfig() (string, error)
+}
+
+// Proxied is implemented by the services which tunnel only the traffic sent
+// through a local proxy, rather than all the traffic of the system.
+type Proxied interface {
+	ProxyURL() *url.URL
+}
+
+var (
+	_ Service = (*ServiceAdapter)(nil)
+	_ Proxied = (*ServiceAdapter)(nil)
+)
+
+type ServiceAdapter struct {
+	service LegacyService
+}
+
+func NewServiceAdapter(v LegacyService) *ServiceAdapter {
+	return &ServiceAdapter{
+		service: v,
+	}
+}
+
+func runSteps(ctx context.Context, steps ...func() error) error {
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := step(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *ServiceAdapter) Info() []byte { return a.service.Info() }
+func (a *ServiceAdapter) IsUp() bool   { return a.service.IsUp() }
+
+func (a *ServiceAdapter) Up(ctx context.Context) error {
+	return runSteps(ctx, a.service.PreUp, a.service.Up, a.service.PostUp)
+}
+
+func (a *ServiceAdapter) Down(ctx context.Context) error {
+	return runSteps(ctx, a.service.PreDown, a.service.Down, a.service.PostDown)
+}
+
+func (a *ServiceAdapter) Health(ctx context.Context) (*Health, error) {
+	if v, ok := a.service.(HealthChecker); ok {
+		return v.Health(ctx)
+	}
+
+	if !a.service.IsUp() {
+		return &Health{State: HealthStateDown}, nil
+	}
+
+	return &Health{
+		State:            HealthStateUp,
+		ProcessAlive:     true,
+		InterfacePresent: true,
+	}, nil
+}
+
+func (a *ServiceAdapter) RenderConfig() (string, error) {
+	if v, ok := a.service.(ConfigRenderer); ok {
+		return v.RenderConfig()
+	}
+
+	return string(a.service.Info()), nil
+}
+
+func (a *ServiceAdapter) ProxyURL() *url.URL {
+	if v, ok := a.service.(Proxied); ok {
+		return v.ProxyURL()
+	}
+
+	return nil
+}
+
+func (a *ServiceAdapter) Stats() (*Stats, error) {
+	if v, ok := a.service.(StatsReporter); ok {
+		return v.Stats()
+	}
+
+	download, upload, err := a.service.Transfer()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stats{
+		Upload:   upload,
+		Download: download,
+	}, nil
+}
+
 type Status struct {
-	From string `json:"from"`
-	ID   uint64 `json:"id"`
-	To   string `json:"to"`
-	Type uint64 `json:"type"`
-	Info []byte `json:"info"`
+	Version uint64 `json:"version"`
+	Name    string `json:"name"`
+	From    string `json:"from"`
+	ID      uint64 `json:"id"`
+	Session uint64 `json:"session"`
+	To      string `json:"to"`
+	Type    uint64 `json:"type"`
+	Info    []byte `json:"info"`
 }
 
 func NewStatus() *Status {
-	return &Status{}
+	return &Status{
+		Version: StatusVersion,
+	}
 }
 
-func (s *Status) WithFrom(v string) *Status { s.From = v; return s }
-func (s *Status) WithID(v uint64) *Status   { s.ID = v; return s }
-func (s *Status) WithInfo(v []byte) *Status { s.Info = v; return s }
-func (s *Status) WithTo(v string) *Status   { s.To = v; return s }
-func (s *Status) WithType(v uint64) *Status { s.Type = v; return s }
+func (s *Status) WithName(v string) *Status    { s.Name = v; return s }
+func (s *Status) WithFrom(v string) *Status    { s.From = v; return s }
+func (s *Status) WithID(v uint64) *Status      { s.ID = v; return s }
+func (s *Status) WithSession(v uint64) *Status { s.Session = v; return s }
+func (s *Status) WithInfo(v []byte) *Status    { s.Info = v; return s }
+func (s *Status) WithTo(v string) *Status      { s.To = v; return s }
+func (s *Status) WithType(v uint64) *Status    { s.Type = v; return s }
 
 func (s *Status) LoadFromPath(path string) error {
-	if _, err := os.Stat(path); err != nil {
-		return nil
-	}
-
 	data, err := os.ReadFile(path)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
 		return err
 	}
-	if len(data) == 0 {
-		return nil
+
+	if err = json.Unmarshal(data, s); err != nil {
+		return fmt.Errorf("%w %s: %s", ErrorCorruptStatus, path, err)
+	}
+
+	// Status files written before the versioning was introduced are compatible with the first version
+	if s.Version == 0 {
+		s.Version = StatusVersion
+	}
+	if s.Version > StatusVersion {
+		return fmt.Errorf("%w %d in %s", ErrorUnsupportedStatus, s.Version, path)
 	}
 
-	return json.Unmarshal(data, s)
+	return nil
 }
 
 func (s *Status) SaveToPath(path string) error {
@@ -57,5 +228,5 @@ func (s *Status) SaveToPath(path string) error {
 		return err
 	}
 
-	return os.WriteFile(path, bytes, 0600)
+	return osutil.WriteFileAtomic(path, bytes, 0600)
 }
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStatusLoadFromPath(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		return path
+	}
+
+	s := &Status{}
+	if err := s.LoadFromPath(write("legacy.json", `{"session":7}`)); err != nil {
+		t.Fatal(err)
+	}
+	if s.Version != StatusVersion || s.Session != 7 {
+		t.Errorf("status written before the versioning loaded as %+v", s)
+	}
+
+	if err := (&Status{}).LoadFromPath(write("newer.json", `{"version":2}`)); !errors.Is(err, ErrorUnsupportedStatus) {
+		t.Errorf("newer status: got %v, want %v", err, ErrorUnsupportedStatus)
+	}
+	if err := (&Status{}).LoadFromPath(write("corrupt.json", `{"session":`)); !errors.Is(err, ErrorCorruptStatus) {
+		t.Errorf("corrupt status: got %v, want %v", err, ErrorCorruptStatus)
+	}
+	if err := (&Status{}).LoadFromPath(filepath.Join(dir, "missing.json")); err != nil {
+		t.Errorf("missing status: %v", err)
+	}
+}
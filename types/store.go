@@ -0,0 +1,215 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	osutil "github.com/sentinel-official/cli-client/utils/os"
+)
+
+const (
+	storeLockTimeout = 5 * time.Second
+)
+
+var (
+	ErrorInvalidStore = errors.New("invalid store file")
+)
+
+// JSONStore keeps items by key in a JSON file; Save merges into the file.
+type JSONStore[T any] struct {
+	mutex  sync.Mutex
+	path   string
+	key    func(T) string
+	dirty  bool
+	items  map[string]T
+	loaded map[string][]byte
+}
+
+func NewJSONStore[T any](path string, key func(T) string) *JSONStore[T] {
+	return &JSONStore[T]{
+		path:   path,
+		key:    key,
+		items:  make(map[string]T),
+		loaded: make(map[string][]byte),
+	}
+}
+
+func (s *JSONStore[T]) Load() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	items, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	return s.reset(items)
+}
+
+// read returns the items in the file.
+func (s *JSONStore[T]) read() (map[string]T, error) {
+	items := make(map[string]T)
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return items, nil
+		}
+
+		return nil, err
+	}
+
+	if s.key == nil {
+		err = json.Unmarshal(data, &items)
+	} else {
+		var list []T
+		if err = json.Unmarshal(data, &list); err == nil {
+			for _, item := range list {
+				items[s.key(item)] = item
+			}
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %s", ErrorInvalidStore, s.path, err)
+	}
+
+	return items, nil
+}
+
+// reset replaces the items, remembering them for telling the changes apart.
+func (s *JSONStore[T]) reset(items map[string]T) error {
+	loaded := make(map[string][]byte, len(items))
+	for key, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+
+		loaded[key] = data
+	}
+
+	s.items, s.loaded = items, loaded
+	return nil
+}
+
+// lock takes the lock of the file, waiting for the other processes saving it.
+func (s *JSONStore[T]) lock() (*osutil.Lock, error) {
+	deadline := time.Now().Add(storeLockTimeout)
+	for {
+		lock, err := osutil.AcquireLock(s.path + ".lock")
+		if err == nil || time.Now().After(deadline) {
+			return lock, err
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// Save writes the items changed since Load over the ones in the file, so
+// that the changes saved by other processes in the meantime are kept.
+func (s *JSONStore[T]) Save() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.dirty {
+		return nil
+	}
+
+	lock, err := s.lock()
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = lock.Release() }()
+
+	items, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	for key, item := range s.items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(data, s.loaded[key]) {
+			items[key] = item
+		}
+	}
+	for key := range s.loaded {
+		if _, ok := s.items[key]; !ok {
+			delete(items, key)
+		}
+	}
+
+	if err = s.reset(items); err != nil {
+		return err
+	}
+
+	var v any = s.items
+	if s.key != nil {
+		v = s.list()
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = osutil.WriteFileAtomic(s.path, data, 0600); err != nil {
+		return err
+	}
+
+	s.dirty = false
+	return nil
+}
+
+// List returns the items sorted by their keys.
+func (s *JSONStore[T]) List() []T {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.list()
+}
+
+func (s *JSONStore[T]) list() []T {
+	keys := make([]string, 0, len(s.items))
+	for key := range s.items {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	items := make([]T, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, s.items[key])
+	}
+
+	return items
+}
+
+// View calls fn with the items, which must not be changed or kept.
+func (s *JSONStore[T]) View(fn func(items map[string]T)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	fn(s.items)
+}
+
+// Update calls fn with the items to change them, and the store is saved by
+// the next Save if fn returns true.
+func (s *JSONStore[T]) Update(fn func(items map[string]T) bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if fn(s.items) {
+		s.dirty = true
+	}
+}
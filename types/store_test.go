@@ -0,0 +1,65 @@
+package types
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func pinKey(v Pin) string { return v.Address }
+
+func TestJSONStoreLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+
+	s := NewJSONStore(path, pinKey)
+	if err := s.Load(); err != nil {
+		t.Errorf("missing file: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"a":{"address":"a"}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewJSONStore(path, pinKey).Load(); !errors.Is(err, ErrorInvalidStore) {
+		t.Errorf("object of a list store: got %v, want %v", err, ErrorInvalidStore)
+	}
+}
+
+func TestJSONStoreSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+
+	unchanged := NewJSONStore(path, pinKey)
+	unchanged.Update(func(items map[string]Pin) bool { items["a"] = Pin{Address: "a"}; return false })
+	if err := unchanged.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("unchanged store was written: %v", err)
+	}
+
+	x, y := NewJSONStore(path, pinKey), NewJSONStore(path, pinKey)
+	for _, s := range []*JSONStore[Pin]{x, y} {
+		if err := s.Load(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Both were loaded before either saved, the changes of both are kept
+	x.Update(func(items map[string]Pin) bool { items["a"] = Pin{Address: "a"}; return true })
+	y.Update(func(items map[string]Pin) bool { items["b"] = Pin{Address: "b"}; return true })
+	for _, s := range []*JSONStore[Pin]{x, y} {
+		if err := s.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loaded := NewJSONStore(path, pinKey)
+	if err := loaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	loaded.View(func(items map[string]Pin) {
+		if len(items) != 2 {
+			t.Errorf("items = %v, want a and b", items)
+		}
+	})
+}
@@ -2,6 +2,8 @@ package net
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -68,3 +70,35 @@ func ToReadable(bytes int64, decimals int) (out string) {
 
 	return fmt.Sprintf("%d.%s%s", i, remString[:decimals], unit)
 }
+
+// ParseBytes parses a size such as 512MB or 5GB, using the same decimal units
+// as ToReadable. A number without a unit is in bytes.
+func ParseBytes(v string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(v))
+
+	units := []struct {
+		suffix string
+		value  int64
+	}{
+		{"TB", TB},
+		{"GB", GB},
+		{"MB", MB},
+		{"KB", KB},
+		{"B", B},
+	}
+
+	unit := B
+	for _, item := range units {
+		if strings.HasSuffix(s, item.suffix) {
+			s, unit = strings.TrimSpace(strings.TrimSuffix(s, item.suffix)), item.value
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %s", v)
+	}
+
+	return int64(n * float64(unit)), nil
+}
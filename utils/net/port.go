@@ -1,6 +1,7 @@
 package net
 
 import (
+	"fmt"
 	"net"
 )
 
@@ -35,3 +36,19 @@ func GetFreeTCPPort() (uint16, error) {
 
 	return uint16(conn.Addr().(*net.TCPAddr).Port), nil
 }
+
+func IsFreeTCPPort(port uint16) bool {
+	addr, err := net.ResolveTCPAddr("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return false
+	}
+
+	conn, err := net.ListenTCP("tcp", addr)
+	if err != nil {
+		return false
+	}
+
+	defer conn.Close()
+
+	return true
+}
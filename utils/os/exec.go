@@ -0,0 +1,43 @@
+package os
+
+import (
+	"context"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	versionRegexp = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+)
+
+// CommandVersion runs the command, which is expected to print the version of
+// the program, and returns the first line of its output.
+func CommandVersion(name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return "", err
+	}
+
+	line, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(line), nil
+}
+
+// ParseVersion returns the major and minor numbers of the first version found
+// in the string.
+func ParseVersion(s string) (major, minor int, ok bool) {
+	m := versionRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, false
+	}
+
+	major, _ = strconv.Atoi(m[1])
+	minor, _ = strconv.Atoi(m[2])
+
+	return major, minor, true
+}
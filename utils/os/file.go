@@ -0,0 +1,32 @@
+package os
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(file.Name(), perm); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
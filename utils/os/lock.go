@@ -0,0 +1,68 @@
+package os
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var (
+	errLocked = errors.New("locked")
+)
+
+type Lock struct {
+	file *os.File
+}
+
+// AcquireLock takes an advisory lock on the file, which the OS releases on exit.
+func AcquireLock(path string) (*Lock, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = lockFile(file); err != nil {
+		data, _ := io.ReadAll(file)
+		_ = file.Close()
+
+		if !errors.Is(err, errLocked) {
+			return nil, err
+		}
+		if pid := strings.TrimSpace(string(data)); pid != "" {
+			return nil, fmt.Errorf("lock %s is held by another process with pid %s", path, pid)
+		}
+
+		return nil, fmt.Errorf("lock %s is held by another process", path)
+	}
+
+	if err = file.Truncate(0); err == nil {
+		_, err = file.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0)
+	}
+	if err != nil {
+		_ = unlockFile(file)
+		_ = file.Close()
+		return nil, err
+	}
+
+	return &Lock{file: file}, nil
+}
+
+// Release unlocks the file; releasing more than once is a no-op.
+func (l *Lock) Release() error {
+	if l.file == nil {
+		return nil
+	}
+
+	_ = l.file.Truncate(0)
+
+	err := unlockFile(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+
+	l.file = nil
+	return err
+}
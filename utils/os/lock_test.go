@@ -0,0 +1,92 @@
+package os
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAcquireLock(t *testing.T) {
+	tests := []struct {
+		name    string
+		content *string
+	}{
+		{"missing file", nil},
+		{"empty file", new(string)},
+		{"stale pid", func() *string { s := "999999"; return &s }()},
+		{"garbage", func() *string { s := "not a pid"; return &s }()},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "test.lock")
+			if tc.content != nil {
+				if err := os.WriteFile(path, []byte(*tc.content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			lock, err := AcquireLock(path)
+			if err != nil {
+				t.Fatalf("AcquireLock() error = %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := string(data), strconv.Itoa(os.Getpid()); got != want {
+				t.Errorf("lock file content = %q, want %q", got, want)
+			}
+
+			if _, err = AcquireLock(path); err == nil || !strings.Contains(err.Error(), "held by another process") {
+				t.Fatalf("second AcquireLock() error = %v, want held", err)
+			}
+
+			if err = lock.Release(); err != nil {
+				t.Fatalf("Release() error = %v", err)
+			}
+			if err = lock.Release(); err != nil {
+				t.Fatalf("second Release() error = %v", err)
+			}
+			if _, err = os.Stat(path); err != nil {
+				t.Fatalf("lock file was removed: %v", err)
+			}
+
+			lock, err = AcquireLock(path)
+			if err != nil {
+				t.Fatalf("AcquireLock() after Release() error = %v", err)
+			}
+
+			_ = lock.Release()
+		})
+	}
+}
+
+// A lock released by one holder must not affect the lock taken by another.
+func TestReleaseKeepsOtherLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+
+	first, err := AcquireLock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = first.Release(); err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := AcquireLock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = second.Release() }()
+
+	if err = first.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = AcquireLock(path); err == nil {
+		t.Fatal("AcquireLock() succeeded while the lock is held")
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package os
+
+import (
+	"os"
+)
+
+func IsPrivileged() bool {
+	return os.Geteuid() == 0
+}
@@ -0,0 +1,17 @@
+package os
+
+import (
+	"os"
+)
+
+// IsPrivileged reports whether the process runs as an Administrator, which
+// is the only one allowed to open the physical drives.
+func IsPrivileged() bool {
+	file, err := os.Open(`\\.\PHYSICALDRIVE0`)
+	if err != nil {
+		return false
+	}
+
+	_ = file.Close()
+	return true
+}
@@ -6,10 +6,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/olekukonko/tablewriter"
 	deposittypes "github.com/sentinel-official/hub/x/deposit/types"
 	"github.com/spf13/cobra"
 
+	clienttypes "github.com/sentinel-official/cli-client/types"
 	"github.com/sentinel-official/cli-client/x/deposit/types"
 )
 
@@ -86,20 +88,34 @@ func QueryDeposits() *cobra.Command {
 				return err
 			}
 
+			paginate, err := clienttypes.NewPaginateOptionsFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
 			var (
 				qsc = deposittypes.NewQueryServiceClient(ctx)
 			)
 
-			result, err := qsc.QueryDeposits(
-				context.Background(),
-				deposittypes.NewQueryDepositsRequest(pagination),
+			deposits, err := clienttypes.Paginate(cmd.Context(), pagination, paginate,
+				func(pagination *query.PageRequest) ([]deposittypes.Deposit, *query.PageResponse, error) {
+					result, err := qsc.QueryDeposits(
+						context.Background(),
+						deposittypes.NewQueryDepositsRequest(pagination),
+					)
+					if err != nil {
+						return nil, nil, err
+					}
+
+					return result.Deposits, result.Pagination, nil
+				},
 			)
 			if err != nil {
 				return err
 			}
 
 			var (
-				items = types.NewDepositsFromRaw(result.Deposits)
+				items = types.NewDepositsFromRaw(deposits)
 				table = tablewriter.NewWriter(cmd.OutOrStdout())
 			)
 
@@ -120,6 +136,7 @@ func QueryDeposits() *cobra.Command {
 
 	flags.AddQueryFlagsToCmd(cmd)
 	flags.AddPaginationFlagsToCmd(cmd, "deposits")
+	clienttypes.AddPaginateFlagsToCmd(cmd)
 
 	return cmd
 }
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+type TLSConfigFunc func(address string) *tls.Config
+
+// Client talks to the HTTP API of the nodes. It keeps one pooled transport
+// per node, since the certificate of each node is verified separately.
+type Client struct {
+	cfg        Config
+	tlsConfig  TLSConfigFunc
+	mutex      sync.Mutex
+	transports map[string]*http.Transport
+}
+
+func NewClient(cfg Config) *Client {
+	return &Client{
+		cfg:        cfg,
+		transports: make(map[string]*http.Transport),
+	}
+}
+
+func (c *Client) WithTLSConfig(v TLSConfigFunc) *Client {
+	c.tlsConfig = v
+	return c
+}
+
+func (c *Client) Config() Config {
+	return c.cfg
+}
+
+// CloseIdleConnections closes the pooled connections of all the nodes.
+func (c *Client) CloseIdleConnections() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, transport := range c.transports {
+		transport.CloseIdleConnections()
+	}
+}
+
+func (c *Client) newTLSConfig(address string) *tls.Config {
+	cfg := &tls.Config{
+		InsecureSkipVerify: true,
+	}
+	if c.tlsConfig != nil {
+		cfg = c.tlsConfig(address).Clone()
+	}
+
+	if verify := cfg.VerifyPeerCertificate; verify != nil {
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, chains [][]*x509.Certificate) error {
+			if err := verify(rawCerts, chains); err != nil {
+				return &certificateError{err: err}
+			}
+
+			return nil
+		}
+	}
+
+	return cfg
+}
+
+func (c *Client) transport(address string) *http.Transport {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if transport, ok := c.transports[address]; ok {
+		return transport
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   c.cfg.DialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       c.newTLSConfig(address),
+		TLSHandshakeTimeout:   c.cfg.TLSTimeout,
+		ResponseHeaderTimeout: c.cfg.ResponseTimeout,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConnsPerHost:   2,
+		ForceAttemptHTTP2:     true,
+	}
+	if c.cfg.Proxy != nil {
+		transport.Proxy = http.ProxyURL(c.cfg.Proxy)
+	}
+
+	c.transports[address] = transport
+	return transport
+}
+
+// backoff returns the doubling delay of the retry, with a jitter of ±50%.
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.cfg.RetryDelay << attempt
+	return delay/2 + time.Duration(rand.Int63n(int64(delay)+1))
+}
+
+// do sends the request, retrying the transient failures where it is safe to.
+func (c *Client) do(
+	ctx context.Context, address, method, endpoint string, data []byte,
+) (result interface{}, elapsed time.Duration, err error) {
+	httpClient := &http.Client{
+		Transport: c.transport(address),
+		Timeout:   c.cfg.Timeout,
+	}
+
+	for attempt := 0; ; attempt++ {
+		result, elapsed, err = c.try(ctx, httpClient, method, endpoint, data)
+		if err == nil {
+			return result, elapsed, nil
+		}
+
+		apiErr := newError(endpoint, err)
+		if attempt >= c.cfg.Retries || !apiErr.Transient() || (!idempotent(method) && apiErr.Sent()) {
+			return nil, elapsed, apiErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, elapsed, newError(endpoint, ctx.Err())
+		case <-time.After(c.backoff(attempt)):
+		}
+	}
+}
+
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+func (c *Client) try(
+	ctx context.Context, httpClient *http.Client, method, endpoint string, data []byte,
+) (interface{}, time.Duration, error) {
+	var body io.Reader = http.NoBody
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.cfg.UserAgent != "" {
+		req.Header.Set("User-Agent", c.cfg.UserAgent)
+	}
+
+	startTime := time.Now()
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, time.Since(startTime), err
+	}
+
+	elapsed := time.Since(startTime)
+	defer resp.Body.Close()
+
+	var res clienttypes.Response
+	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		if resp.StatusCode >= 300 {
+			return nil, elapsed, &StatusError{Code: resp.StatusCode}
+		}
+
+		return nil, elapsed, err
+	}
+	if res.Error != nil {
+		return nil, elapsed, res.Error
+	}
+	if resp.StatusCode >= 300 {
+		return nil, elapsed, &StatusError{Code: resp.StatusCode}
+	}
+
+	return res.Result, elapsed, nil
+}
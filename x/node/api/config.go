@@ -0,0 +1,120 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/spf13/cobra"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+type Config struct {
+	DialTimeout     time.Duration
+	TLSTimeout      time.Duration
+	ResponseTimeout time.Duration
+	Timeout         time.Duration
+	Retries         int
+	RetryDelay      time.Duration
+	Proxy           *url.URL
+	UserAgent       string
+}
+
+func DefaultUserAgent() string {
+	name := version.AppName
+	if name == "" {
+		name = "sentinelcli"
+	}
+
+	v := version.Version
+	if v == "" {
+		v = "unknown"
+	}
+
+	return fmt.Sprintf("%s/%s", name, v)
+}
+
+func DefaultConfig() Config {
+	return Config{
+		DialTimeout:     5 * time.Second,
+		TLSTimeout:      5 * time.Second,
+		ResponseTimeout: 10 * time.Second,
+		Timeout:         15 * time.Second,
+		Retries:         2,
+		RetryDelay:      500 * time.Millisecond,
+		UserAgent:       DefaultUserAgent(),
+	}
+}
+
+func (c Config) Validate() error {
+	if c.DialTimeout < 0 || c.TLSTimeout < 0 || c.ResponseTimeout < 0 || c.Timeout < 0 {
+		return fmt.Errorf("timeouts cannot be negative")
+	}
+	if c.Retries < 0 {
+		return fmt.Errorf("retries cannot be negative")
+	}
+	if c.Proxy != nil {
+		switch c.Proxy.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("unsupported proxy scheme %s", c.Proxy.Scheme)
+		}
+	}
+
+	return nil
+}
+
+func AddFlagsToCmd(cmd *cobra.Command) {
+	cfg := DefaultConfig()
+
+	cmd.Flags().Duration(clienttypes.FlagTimeout, cfg.Timeout, "time limit for each request made by the HTTP client")
+	cmd.Flags().Duration(clienttypes.FlagHTTPDialTimeout, cfg.DialTimeout, "time limit for connecting to a node")
+	cmd.Flags().Duration(clienttypes.FlagHTTPTLSTimeout, cfg.TLSTimeout, "time limit for the TLS handshake with a node")
+	cmd.Flags().Duration(clienttypes.FlagHTTPResponseTimeout, cfg.ResponseTimeout, "time limit for waiting on the response headers of a node")
+	cmd.Flags().Int(clienttypes.FlagHTTPRetries, cfg.Retries, "number of retries for transient failures of the requests made to a node")
+	cmd.Flags().String(clienttypes.FlagHTTPProxy, "", "outbound proxy for reaching the nodes (http|https|socks5://host:port)")
+}
+
+func NewConfigFromCmd(cmd *cobra.Command) (cfg Config, err error) {
+	cfg = DefaultConfig()
+
+	cfg.Timeout, err = cmd.Flags().GetDuration(clienttypes.FlagTimeout)
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.DialTimeout, err = cmd.Flags().GetDuration(clienttypes.FlagHTTPDialTimeout)
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.TLSTimeout, err = cmd.Flags().GetDuration(clienttypes.FlagHTTPTLSTimeout)
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.ResponseTimeout, err = cmd.Flags().GetDuration(clienttypes.FlagHTTPResponseTimeout)
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.Retries, err = cmd.Flags().GetInt(clienttypes.FlagHTTPRetries)
+	if err != nil {
+		return cfg, err
+	}
+
+	proxy, err := cmd.Flags().GetString(clienttypes.FlagHTTPProxy)
+	if err != nil {
+		return cfg, err
+	}
+	if proxy != "" {
+		cfg.Proxy, err = url.Parse(proxy)
+		if err != nil {
+			return cfg, err
+		}
+	}
+
+	return cfg, cfg.Validate()
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+const (
+	ErrorKindDNS        = "dns"
+	ErrorKindTLS        = "tls"
+	ErrorKindTimeout    = "timeout"
+	ErrorKindConnection = "connection"
+	ErrorKindResponse   = "response"
+	ErrorKindNode       = "node"
+)
+
+// Error is returned for all the failed requests to a node. The kind tells
+// apart the failures of the network from the errors returned by the node.
+type Error struct {
+	Kind     string
+	Endpoint string
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s error for %s: %s", e.Kind, e.Endpoint, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Transient reports whether the request may succeed when retried.
+func (e *Error) Transient() bool {
+	switch e.Kind {
+	case ErrorKindTimeout, ErrorKindConnection:
+		return true
+	case ErrorKindDNS:
+		var dnsErr *net.DNSError
+		return errors.As(e.Err, &dnsErr) && (dnsErr.IsTemporary || dnsErr.IsTimeout)
+	case ErrorKindResponse:
+		var statusErr *StatusError
+		return errors.As(e.Err, &statusErr) && statusErr.Code >= 500
+	default:
+		return false
+	}
+}
+
+// Sent reports whether the request may have reached the node, that is it
+// did not fail while resolving the name of the node or dialing it.
+func (e *Error) Sent() bool {
+	var (
+		dnsErr *net.DNSError
+		opErr  *net.OpError
+	)
+
+	if errors.As(e.Err, &dnsErr) {
+		return false
+	}
+	if errors.As(e.Err, &opErr) && (opErr.Op == "dial" || opErr.Op == "proxyconnect") {
+		return false
+	}
+
+	return true
+}
+
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d", e.Code)
+}
+
+// certificateError wraps the errors returned while verifying the certificate
+// of a node, so they can be told apart from the other handshake failures.
+type certificateError struct {
+	err error
+}
+
+func (e *certificateError) Error() string {
+	return e.err.Error()
+}
+
+func (e *certificateError) Unwrap() error {
+	return e.err
+}
+
+func newError(endpoint string, err error) *Error {
+	return &Error{
+		Kind:     errorKind(err),
+		Endpoint: endpoint,
+		Err:      err,
+	}
+}
+
+func errorKind(err error) string {
+	var (
+		nodeErr    *clienttypes.Error
+		statusErr  *StatusError
+		dnsErr     *net.DNSError
+		certErr    *certificateError
+		recordErr  tls.RecordHeaderError
+		unknownErr x509.UnknownAuthorityError
+		invalidErr x509.CertificateInvalidError
+		netErr     net.Error
+		opErr      *net.OpError
+	)
+
+	switch {
+	case errors.As(err, &nodeErr):
+		return ErrorKindNode
+	case errors.As(err, &statusErr):
+		return ErrorKindResponse
+	case errors.As(err, &dnsErr):
+		return ErrorKindDNS
+	case errors.As(err, &certErr), errors.As(err, &recordErr),
+		errors.As(err, &unknownErr), errors.As(err, &invalidErr):
+		return ErrorKindTLS
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorKindTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorKindTimeout
+	case strings.Contains(err.Error(), "tls:"):
+		return ErrorKindTLS
+	case errors.As(err, &opErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrorKindConnection
+	default:
+		return ErrorKindResponse
+	}
+}
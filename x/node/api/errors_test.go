@@ -0,0 +1,27 @@
+package api
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+func TestErrorSent(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&net.DNSError{Err: "no such host", IsNotFound: true}, false},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, false},
+		{&net.OpError{Op: "read", Err: errors.New("connection reset by peer")}, true},
+		{clienttypes.NewError("session", 1, "invalid signature"), true},
+	}
+
+	for _, tc := range tests {
+		if got := newError("https://node", tc.err).Sent(); got != tc.want {
+			t.Errorf("Sent() of %v = %t, want %t", tc.err, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	nodetypes "github.com/sentinel-official/hub/x/node/types"
+
+	"github.com/sentinel-official/cli-client/x/node/types"
+)
+
+// FetchInfo returns the status reported by the node, with the latency set to
+// the duration of the request.
+func (c *Client) FetchInfo(ctx context.Context, address, remoteURL string) (info types.Info, err error) {
+	endpoint, err := url.JoinPath(remoteURL, "status")
+	if err != nil {
+		return info, err
+	}
+
+	result, elapsed, err := c.do(ctx, address, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return info, err
+	}
+
+	buf, err := json.Marshal(result)
+	if err != nil {
+		return info, err
+	}
+
+	if err = json.Unmarshal(buf, &info); err != nil {
+		return info, newError(endpoint, err)
+	}
+
+	info.Latency = elapsed
+	return info, nil
+}
+
+// FetchCertificate returns the certificate presented by the node, which is
+// verified only as configured by WithTLSConfig.
+func (c *Client) FetchCertificate(ctx context.Context, address, remoteURL string) ([]byte, error) {
+	endpoint, err := url.JoinPath(remoteURL, "status")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{
+		Transport: c.transport(address),
+		Timeout:   c.cfg.Timeout,
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, newError(endpoint, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.TLS == nil || len(resp.TLS.PeerCertificates) == 0 {
+		return nil, fmt.Errorf("node %s did not present a certificate", address)
+	}
+
+	return resp.TLS.PeerCertificates[0].Raw, nil
+}
+
+// FetchInfos fetches the nodes concurrently, calling fn serially with each result.
+func (c *Client) FetchInfos(
+	ctx context.Context, nodes []nodetypes.Node, concurrency int, fn func(node *nodetypes.Node, info types.Info, err error),
+) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		jobs  = make(chan *nodetypes.Node)
+		group = sync.WaitGroup{}
+		mutex = sync.Mutex{}
+	)
+
+	for i := 0; i < concurrency; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+
+			for node := range jobs {
+				info, err := c.FetchInfo(ctx, node.Address, node.RemoteURL)
+
+				mutex.Lock()
+				fn(node, info, err)
+				mutex.Unlock()
+			}
+		}()
+	}
+
+loop:
+	for i := range nodes {
+		select {
+		case jobs <- &nodes[i]:
+		case <-ctx.Done():
+			break loop
+		}
+	}
+
+	close(jobs)
+	group.Wait()
+}
+
+// AddSession sends the key of the client for the session to the node and
+// returns the result which is used for configuring the service.
+func (c *Client) AddSession(
+	ctx context.Context, address, remoteURL string, from sdk.AccAddress, id uint64, key string, signature []byte,
+) ([]byte, error) {
+	endpoint, err := url.JoinPath(remoteURL, fmt.Sprintf("/accounts/%s/sessions/%d", from, id))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(
+		map[string]interface{}{
+			"key":       key,
+			"signature": signature,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result, _, err := c.do(ctx, address, http.MethodPost, endpoint, data)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := result.(string)
+	if !ok {
+		return nil, newError(endpoint, fmt.Errorf("invalid result type %T", result))
+	}
+
+	return base64.StdEncoding.DecodeString(s)
+}
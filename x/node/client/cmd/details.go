@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/olekukonko/tablewriter"
+	hubtypes "github.com/sentinel-official/hub/types"
+	nodetypes "github.com/sentinel-official/hub/x/node/types"
+	plantypes "github.com/sentinel-official/hub/x/plan/types"
+	sessiontypes "github.com/sentinel-official/hub/x/session/types"
+	"github.com/spf13/cobra"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+	"github.com/sentinel-official/cli-client/x/node/types"
+	planinfotypes "github.com/sentinel-official/cli-client/x/plan/types"
+	sessioninfotypes "github.com/sentinel-official/cli-client/x/session/types"
+)
+
+var (
+	detailsHeader = []string{
+		"Field",
+		"Value",
+	}
+	plansHeader = []string{
+		"ID",
+		"Provider",
+		"Prices",
+		"Gigabytes",
+		"Duration",
+		"Status",
+	}
+	sessionsHeader = []string{
+		"ID",
+		"Subscription",
+		"Duration",
+		"Bandwidth",
+		"Status",
+		"Status at",
+	}
+)
+
+// nodeDetails is the node along with the plans it is linked to and the
+// sessions of an account with it.
+type nodeDetails struct {
+	Node       types.Node                `json:"node"`
+	Error      string                    `json:"error,omitempty"`
+	Plans      planinfotypes.Plans       `json:"plans,omitempty"`
+	Sessions   sessioninfotypes.Sessions `json:"sessions,omitempty"`
+	Mismatches types.Mismatches          `json:"mismatches,omitempty"`
+}
+
+func detailRows(item types.Node, fetchErr error) [][]string {
+	rows := [][]string{
+		{"Moniker", item.Moniker},
+		{"Address", item.Address},
+		{"Operator", item.Operator},
+		{"Type", clienttypes.ServiceName(item.Type)},
+		{"Version", item.Version},
+		{"Remote URL", item.RemoteURL},
+		{"Status", item.Status},
+		{"Status at", item.StatusAt.Format(time.RFC3339)},
+		{"Gigabyte prices", item.GigabytePrices.Raw().String()},
+		{"Hourly prices", item.HourlyPrices.Raw().String()},
+		{"Country", item.Location.Country},
+		{"City", item.Location.City},
+		{"Coordinates", fmt.Sprintf("%.4f, %.4f", item.Location.Latitude, item.Location.Longitude)},
+		{"Speed test", item.Bandwidth.String()},
+		{"Latency", item.Latency.Truncate(1 * time.Millisecond).String()},
+		{"Peers", fmt.Sprintf("%d", item.Peers)},
+		{"Handshake", fmt.Sprintf("%t", item.Handshake.Enable)},
+		{"Handshake peers", fmt.Sprintf("%d", item.Handshake.Peers)},
+		{"Interval set sessions", item.IntervalSetSessions.String()},
+		{"Interval update sessions", item.IntervalUpdateSessions.String()},
+		{"Interval update status", item.IntervalUpdateStatus.String()},
+	}
+
+	if fetchErr != nil {
+		rows = append(rows, []string{"Error", fetchErr.Error()})
+	}
+
+	return rows
+}
+
+// queryPlansForNode returns the active plans the node is linked to. There is
+// no query for it, so the nodes of each of the active plans are queried.
+func queryPlansForNode(cmd *cobra.Command, ctx client.Context, address string) (planinfotypes.Plans, error) {
+	var (
+		pqc      = plantypes.NewQueryServiceClient(ctx)
+		nqc      = nodetypes.NewQueryServiceClient(ctx)
+		paginate = clienttypes.DefaultPaginateOptions()
+	)
+
+	plans, err := clienttypes.Paginate(cmd.Context(), nil, paginate,
+		func(pagination *query.PageRequest) ([]plantypes.Plan, *query.PageResponse, error) {
+			result, err := pqc.QueryPlans(
+				context.Background(),
+				plantypes.NewQueryPlansRequest(hubtypes.StatusActive, pagination),
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return result.Plans, result.Pagination, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	items := planinfotypes.Plans{}
+	for i := 0; i < len(plans); i++ {
+		nodes, err := clienttypes.Paginate(cmd.Context(), nil, paginate,
+			func(pagination *query.PageRequest) ([]nodetypes.Node, *query.PageResponse, error) {
+				result, err := nqc.QueryNodesForPlan(
+					context.Background(),
+					nodetypes.NewQueryNodesForPlanRequest(plans[i].ID, hubtypes.StatusUnspecified, pagination),
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				return result.Nodes, result.Pagination, nil
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for j := 0; j < len(nodes); j++ {
+			if nodes[j].Address == address {
+				items = append(items, planinfotypes.NewPlanFromRaw(&plans[i]))
+				break
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// accountFromCmd returns the account of --address, else the one of the key of
+// --from, and nil if neither was given.
+func accountFromCmd(cmd *cobra.Command, ctx client.Context) (sdk.AccAddress, error) {
+	bech32Account, err := cmd.Flags().GetString(flagAddress)
+	if err != nil {
+		return nil, err
+	}
+	if bech32Account != "" {
+		return sdk.AccAddressFromBech32(bech32Account)
+	}
+
+	from, err := cmd.Flags().GetString(flags.FlagFrom)
+	if err != nil {
+		return nil, err
+	}
+	if from == "" {
+		return nil, nil
+	}
+
+	account, _, _, err := client.GetFromFields(ctx, ctx.Keyring, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// querySessionsForNode returns the sessions of the account with the node.
+func querySessionsForNode(cmd *cobra.Command, ctx client.Context, account sdk.AccAddress, address string) (sessioninfotypes.Sessions, error) {
+	qsc := sessiontypes.NewQueryServiceClient(ctx)
+
+	sessions, err := clienttypes.Paginate(cmd.Context(), nil, clienttypes.DefaultPaginateOptions(),
+		func(pagination *query.PageRequest) ([]sessiontypes.Session, *query.PageResponse, error) {
+			result, err := qsc.QuerySessionsForAccount(
+				context.Background(),
+				sessiontypes.NewQuerySessionsForAccountRequest(account, pagination),
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return result.Sessions, result.Pagination, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	items := sessioninfotypes.Sessions{}
+	for i := 0; i < len(sessions); i++ {
+		if sessions[i].NodeAddress == address {
+			items = append(items, sessioninfotypes.NewSessionFromRaw(&sessions[i]))
+		}
+	}
+
+	return items, nil
+}
+
+// printDetails renders the node vertically, followed by the plans, the
+// sessions and the verification results which were asked for.
+func printDetails(cmd *cobra.Command, details nodeDetails, fetchErr error, plans, sessions, verify bool) {
+	table := tablewriter.NewWriter(cmd.OutOrStdout())
+	table.SetHeader(detailsHeader)
+	table.SetAutoWrapText(false)
+	table.AppendBulk(detailRows(details.Node, fetchErr))
+	table.Render()
+
+	if plans {
+		cmd.Println("Plans")
+
+		table = tablewriter.NewWriter(cmd.OutOrStdout())
+		table.SetHeader(plansHeader)
+
+		for _, item := range details.Plans {
+			table.Append(
+				[]string{
+					fmt.Sprintf("%d", item.ID),
+					item.Address,
+					item.Prices.Raw().String(),
+					fmt.Sprintf("%d", item.Gigabytes),
+					item.Duration.String(),
+					item.Status,
+				},
+			)
+		}
+
+		table.Render()
+	}
+
+	if sessions {
+		cmd.Println("Sessions")
+
+		table = tablewriter.NewWriter(cmd.OutOrStdout())
+		table.SetHeader(sessionsHeader)
+
+		for _, item := range details.Sessions {
+			table.Append(
+				[]string{
+					fmt.Sprintf("%d", item.ID),
+					fmt.Sprintf("%d", item.SubscriptionID),
+					item.Duration.Truncate(1 * time.Second).String(),
+					item.Bandwidth.String(),
+					item.Status,
+					item.StatusAt.Format(time.RFC3339),
+				},
+			)
+		}
+
+		table.Render()
+	}
+
+	if !verify || fetchErr != nil {
+		return
+	}
+	if len(details.Mismatches) == 0 {
+		cmd.Println("Node information matches the chain")
+		return
+	}
+
+	table = tablewriter.NewWriter(cmd.OutOrStdout())
+	table.SetHeader(mismatchHeader)
+
+	for _, item := range details.Mismatches {
+		table.Append(
+			[]string{
+				item.Field,
+				item.OnChain,
+				item.Reported,
+				fmt.Sprintf("%t", item.Fatal),
+			},
+		)
+	}
+
+	table.Render()
+}
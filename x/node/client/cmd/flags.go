@@ -1,6 +1,31 @@
 package cmd
 
 const (
-	flagPlanID = "plan-id"
-	flagStatus = "status"
+	flagAddress          = "address"
+	flagCity             = "city"
+	flagConcurrency      = "concurrency"
+	flagCountry          = "country"
+	flagHandshake        = "handshake"
+	flagMaxGigabytePrice = "max-gigabyte-price"
+	flagMaxHourlyPrice   = "max-hourly-price"
+	flagMaxLatency       = "max-latency"
+	flagMaxPeers         = "max-peers"
+	flagMinBandwidth     = "min-bandwidth"
+	flagMinVersion       = "min-version"
+	flagPlanID           = "plan-id"
+	flagPlans            = "plans"
+	flagShowBlocked      = "show-blocked"
+	flagSkipProbe        = "skip-probe"
+	flagSortBy           = "sort-by"
+	flagStatus           = "status"
+	flagType             = "type"
+	flagVerify           = "verify"
+)
+
+const (
+	outputGeoJSON   = "geojson"
+	outputJSON      = "json"
+	outputJSONLines = "jsonl"
+	outputKML       = "kml"
+	outputText      = "text"
 )
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// progress reports the number of the nodes probed so far, if the writer is a
+// terminal.
+type progress struct {
+	w     io.Writer
+	total int
+	count int
+}
+
+func newProgress(w io.Writer, total int) *progress {
+	if f, ok := w.(*os.File); !ok || !term.IsTerminal(int(f.Fd())) {
+		w = nil
+	}
+
+	return &progress{
+		w:     w,
+		total: total,
+	}
+}
+
+func (p *progress) add() {
+	p.count++
+	if p.w != nil {
+		_, _ = fmt.Fprintf(p.w, "\rProbed %d of %d nodes", p.count, p.total)
+	}
+}
+
+func (p *progress) done() {
+	if p.w != nil && p.count > 0 {
+		_, _ = fmt.Fprint(p.w, "\r\x1b[K")
+	}
+}
@@ -2,22 +2,23 @@ package cmd
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"net/http"
-	"strings"
-	"sync"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/olekukonko/tablewriter"
 	hubtypes "github.com/sentinel-official/hub/types"
 	nodetypes "github.com/sentinel-official/hub/x/node/types"
 	"github.com/spf13/cobra"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
 
 	clienttypes "github.com/sentinel-official/cli-client/types"
+	netutil "github.com/sentinel-official/cli-client/utils/net"
+	"github.com/sentinel-official/cli-client/x/node/api"
 	"github.com/sentinel-official/cli-client/x/node/types"
 )
 
@@ -36,49 +37,132 @@ var (
 		"Version",
 		"Status",
 	}
-	nodeTypes = map[uint64]string{
-		1: "WireGuard",
-		2: "V2Ray",
+	mismatchHeader = []string{
+		"Field",
+		"On chain",
+		"Reported",
+		"Fatal",
 	}
 )
 
-func fetchNodeInfo(remote string, timeout time.Duration) (info types.Info, err error) {
+func nodeRow(item types.Node) []string {
+	moniker := item.Moniker
+	if item.Favorite {
+		moniker = "* " + moniker
+	}
+
+	return []string{
+		moniker,
+		item.Address,
+		item.GigabytePrices.Raw().String(),
+		item.HourlyPrices.Raw().String(),
+		item.Location.Country,
+		item.Bandwidth.String(),
+		item.Latency.Truncate(1 * time.Millisecond).String(),
+		fmt.Sprintf("%d", item.Peers),
+		fmt.Sprintf("%t", item.Handshake.Enable),
+		clienttypes.ServiceName(item.Type),
+		item.Version,
+		item.Status,
+	}
+}
+
+func readFilter(cmd *cobra.Command) (*types.Filter, error) {
 	var (
-		body       clienttypes.Response
-		endpoint   = strings.Trim(remote, "/") + "/status"
-		httpclient = &http.Client{
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: true,
-				},
-			},
-			Timeout: timeout,
-		}
-		startTime = time.Now()
+		filter = &types.Filter{}
+		err    error
 	)
 
-	resp, err := httpclient.Get(endpoint)
+	filter.Countries, err = cmd.Flags().GetStringSlice(flagCountry)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Cities, err = cmd.Flags().GetStringSlice(flagCity)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := cmd.Flags().GetString(flagType)
 	if err != nil {
-		return info, err
+		return nil, err
 	}
+	if s != "" {
+		definition, err := clienttypes.GetServiceByName(s)
+		if err != nil {
+			return nil, err
+		}
 
-	info.Latency = time.Since(startTime)
-	defer resp.Body.Close()
+		filter.Type = definition.Type
+	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
-		return info, err
+	s, err = cmd.Flags().GetString(flagMinBandwidth)
+	if err != nil {
+		return nil, err
+	}
+	if s != "" {
+		filter.MinBandwidth, err = netutil.ParseBytes(s)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	bytes, err := json.Marshal(body.Result)
+	filter.MaxLatency, err = cmd.Flags().GetDuration(flagMaxLatency)
 	if err != nil {
-		return info, err
+		return nil, err
 	}
 
-	if err := json.Unmarshal(bytes, &info); err != nil {
-		return info, err
+	s, err = cmd.Flags().GetString(flagMaxGigabytePrice)
+	if err != nil {
+		return nil, err
+	}
+	if s != "" {
+		filter.MaxGigabytePrice, err = sdk.ParseCoinsNormalized(s)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return info, nil
+	s, err = cmd.Flags().GetString(flagMaxHourlyPrice)
+	if err != nil {
+		return nil, err
+	}
+	if s != "" {
+		filter.MaxHourlyPrice, err = sdk.ParseCoinsNormalized(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	filter.MinVersion, err = cmd.Flags().GetString(flagMinVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Handshake, err = cmd.Flags().GetBool(flagHandshake)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.MaxPeers, err = cmd.Flags().GetInt(flagMaxPeers)
+	if err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// priceDenom returns the denom the prices are compared in for sorting, the
+// first one of the price filters if given.
+func priceDenom(filter *types.Filter) string {
+	switch {
+	case len(filter.MaxGigabytePrice) > 0:
+		return filter.MaxGigabytePrice[0].Denom
+	case len(filter.MaxHourlyPrice) > 0:
+		return filter.MaxHourlyPrice[0].Denom
+	default:
+		return types.DefaultPriceDenom
+	}
 }
 
 func QueryNode() *cobra.Command {
@@ -97,11 +181,44 @@ func QueryNode() *cobra.Command {
 				return err
 			}
 
-			timeout, err := cmd.Flags().GetDuration(clienttypes.FlagTimeout)
+			apiConfig, err := api.NewConfigFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
+			strictTLS, err := cmd.Flags().GetBool(clienttypes.FlagTLSStrict)
+			if err != nil {
+				return err
+			}
+
+			verify, err := cmd.Flags().GetBool(flagVerify)
+			if err != nil {
+				return err
+			}
+
+			plans, err := cmd.Flags().GetBool(flagPlans)
+			if err != nil {
+				return err
+			}
+
+			account, err := accountFromCmd(cmd, ctx)
 			if err != nil {
 				return err
 			}
 
+			output, err := cmd.Flags().GetString(tmcli.OutputFlag)
+			if err != nil {
+				return err
+			}
+			if output != outputText && output != outputJSON {
+				return fmt.Errorf("invalid output %s, expected one of %s, %s", output, outputText, outputJSON)
+			}
+
+			pins := clienttypes.NewPinStore(ctx.HomeDir, cmd.ErrOrStderr())
+			if err = pins.Load(); err != nil {
+				return err
+			}
+
 			var (
 				qsc = nodetypes.NewQueryServiceClient(ctx)
 			)
@@ -114,38 +231,70 @@ func QueryNode() *cobra.Command {
 				return err
 			}
 
-			var (
-				info, _ = fetchNodeInfo(result.Node.RemoteURL, timeout)
-				item    = types.NewNodeFromRaw(&result.Node).WithInfo(info)
-				table   = tablewriter.NewWriter(cmd.OutOrStdout())
-			)
+			apiClient := api.NewClient(apiConfig).WithTLSConfig(pins.TLSConfigFunc(strictTLS))
 
-			table.SetHeader(header)
-			table.Append(
-				[]string{
-					item.Moniker,
-					item.Address,
-					item.GigabytePrices.Raw().String(),
-					item.HourlyPrices.Raw().String(),
-					item.Location.Country,
-					item.Bandwidth.String(),
-					item.Latency.Truncate(1 * time.Millisecond).String(),
-					fmt.Sprintf("%d", item.Peers),
-					fmt.Sprintf("%t", item.Handshake.Enable),
-					nodeTypes[item.Type],
-					item.Version,
-					item.Status,
-				},
-			)
+			info, fetchErr := apiClient.FetchInfo(cmd.Context(), result.Node.Address, result.Node.RemoteURL)
+
+			if err = pins.Save(); err != nil {
+				return err
+			}
+
+			details := nodeDetails{
+				Node: types.NewNodeFromRaw(&result.Node).WithInfo(info),
+			}
+			if fetchErr != nil {
+				details.Error = fetchErr.Error()
+			}
+
+			if plans {
+				details.Plans, err = queryPlansForNode(cmd, ctx, address.String())
+				if err != nil {
+					return err
+				}
+			}
+
+			if account != nil {
+				details.Sessions, err = querySessionsForNode(cmd, ctx, account, address.String())
+				if err != nil {
+					return err
+				}
+			}
+
+			if verify && fetchErr == nil {
+				details.Mismatches = types.Verify(&result.Node, info)
+			}
+
+			if output == outputJSON {
+				if err = json.NewEncoder(cmd.OutOrStdout()).Encode(details); err != nil {
+					return err
+				}
+			} else {
+				printDetails(cmd, details, fetchErr, plans, account != nil, verify)
+			}
+
+			if !verify {
+				return nil
+			}
+			if fetchErr != nil {
+				return fmt.Errorf("failed to fetch the node information: %w", fetchErr)
+			}
+			if len(details.Mismatches.Fatal()) > 0 {
+				return fmt.Errorf("node %s failed the identity verification", address)
+			}
 
-			table.Render()
 			return nil
 		},
 	}
 
 	flags.AddQueryFlagsToCmd(cmd)
+	api.AddFlagsToCmd(cmd)
 
-	cmd.Flags().Duration(clienttypes.FlagTimeout, 15*time.Second, "time limit for requests made by the HTTP client")
+	cmd.Flags().Bool(clienttypes.FlagTLSStrict, false, "fail if the certificate of a node does not match the pinned one")
+	cmd.Flags().Bool(flagVerify, false, "cross-check the information reported by the node with the chain")
+	cmd.Flags().Bool(flagPlans, false, "look up the plans of the node, which queries the nodes of every active plan")
+	cmd.Flags().String(flagAddress, "", "account address whose sessions with the node are listed, instead of the one of --from")
+	cmd.Flags().String(flags.FlagFrom, "", "name or address of the key whose sessions with the node are listed")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "select keyring's backend (os|file|kwallet|pass|test|memory)")
 
 	return cmd
 }
@@ -170,16 +319,109 @@ func QueryNodes() *cobra.Command {
 				return err
 			}
 
-			timeout, err := cmd.Flags().GetDuration(clienttypes.FlagTimeout)
+			filter, err := readFilter(cmd)
+			if err != nil {
+				return err
+			}
+
+			sortBy, err := cmd.Flags().GetString(flagSortBy)
+			if err != nil {
+				return err
+			}
+
+			var desc bool
+			if sortBy != "" {
+				sortBy, desc, err = types.ParseSortBy(sortBy)
+				if err != nil {
+					return err
+				}
+			}
+
+			output, err := cmd.Flags().GetString(tmcli.OutputFlag)
+			if err != nil {
+				return err
+			}
+
+			switch output {
+			case outputText, outputJSON, outputGeoJSON, outputKML:
+			case outputJSONLines:
+				if sortBy != "" {
+					return fmt.Errorf("--%s cannot be used with the %s output", flagSortBy, outputJSONLines)
+				}
+			default:
+				return fmt.Errorf("invalid output %s, expected one of %s, %s, %s, %s, %s",
+					output, outputText, outputJSON, outputJSONLines, outputGeoJSON, outputKML)
+			}
+
+			concurrency, err := cmd.Flags().GetInt(flagConcurrency)
+			if err != nil {
+				return err
+			}
+			if concurrency < 1 {
+				return fmt.Errorf("invalid concurrency %d", concurrency)
+			}
+
+			skipProbe, err := cmd.Flags().GetBool(flagSkipProbe)
+			if err != nil {
+				return err
+			}
+
+			cacheTTL, err := cmd.Flags().GetDuration(clienttypes.FlagCacheTTL)
+			if err != nil {
+				return err
+			}
+
+			showBlocked, err := cmd.Flags().GetBool(flagShowBlocked)
+			if err != nil {
+				return err
+			}
+
+			favorites := clienttypes.NewFavorites(ctx.HomeDir)
+			if err = favorites.Load(); err != nil {
+				return err
+			}
+
+			blocked := clienttypes.NewBlocked(ctx.HomeDir)
+			if err = blocked.Load(); err != nil {
+				return err
+			}
+
+			cache := types.NewCache(ctx.HomeDir)
+			if err = cache.Load(); err != nil {
+				return err
+			}
+			if skipProbe && filter.NeedsInfo() {
+				return fmt.Errorf("filters on the information reported by the nodes cannot be used with --%s", flagSkipProbe)
+			}
+			if skipProbe && (output == outputGeoJSON || output == outputKML) {
+				return fmt.Errorf("the %s output needs the locations of the nodes, it cannot be used with --%s", output, flagSkipProbe)
+			}
+
+			apiConfig, err := api.NewConfigFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
+			strictTLS, err := cmd.Flags().GetBool(clienttypes.FlagTLSStrict)
 			if err != nil {
 				return err
 			}
 
+			pins := clienttypes.NewPinStore(ctx.HomeDir, cmd.ErrOrStderr())
+			if err = pins.Load(); err != nil {
+				return err
+			}
+
 			pagination, err := client.ReadPageRequest(cmd.Flags())
 			if err != nil {
 				return err
 			}
 
+			paginate, err := clienttypes.NewPaginateOptionsFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
 			var (
 				items  []nodetypes.Node
 				qsc    = nodetypes.NewQueryServiceClient(ctx)
@@ -187,74 +429,138 @@ func QueryNodes() *cobra.Command {
 			)
 
 			if planID != 0 {
-				result, err := qsc.QueryNodesForPlan(
-					context.Background(),
-					nodetypes.NewQueryNodesForPlanRequest(
-						planID,
-						status,
-						pagination,
-					),
+				items, err = clienttypes.Paginate(cmd.Context(), pagination, paginate,
+					func(pagination *query.PageRequest) ([]nodetypes.Node, *query.PageResponse, error) {
+						result, err := qsc.QueryNodesForPlan(
+							context.Background(),
+							nodetypes.NewQueryNodesForPlanRequest(
+								planID,
+								status,
+								pagination,
+							),
+						)
+						if err != nil {
+							return nil, nil, err
+						}
+
+						return result.Nodes, result.Pagination, nil
+					},
 				)
-				if err != nil {
-					return err
-				}
-
-				items = append(items, result.Nodes...)
 			} else {
-				result, err := qsc.QueryNodes(
-					context.Background(),
-					nodetypes.NewQueryNodesRequest(
-						status,
-						pagination,
-					),
+				items, err = clienttypes.Paginate(cmd.Context(), pagination, paginate,
+					func(pagination *query.PageRequest) ([]nodetypes.Node, *query.PageResponse, error) {
+						result, err := qsc.QueryNodes(
+							context.Background(),
+							nodetypes.NewQueryNodesRequest(
+								status,
+								pagination,
+							),
+						)
+						if err != nil {
+							return nil, nil, err
+						}
+
+						return result.Nodes, result.Pagination, nil
+					},
 				)
-				if err != nil {
-					return err
+			}
+			if err != nil {
+				return err
+			}
+
+			// The blocked nodes are dropped before probing, they are not shown anyway
+			if !showBlocked {
+				n := 0
+				for i := 0; i < len(items); i++ {
+					if !blocked.Has(items[i].Address) {
+						items[n] = items[i]
+						n++
+					}
 				}
 
-				items = append(items, result.Nodes...)
+				items = items[:n]
 			}
 
 			var (
-				group = sync.WaitGroup{}
-				mutex = sync.Mutex{}
-				table = tablewriter.NewWriter(cmd.OutOrStdout())
+				apiClient = api.NewClient(apiConfig).WithTLSConfig(pins.TLSConfigFunc(strictTLS))
+				nodes     = make(types.Nodes, 0, len(items))
+				emit      = func(item types.Node) { nodes = append(nodes, item) }
+				newNode   = func(v *nodetypes.Node) types.Node {
+					item := types.NewNodeFromRaw(v)
+					item.Favorite = favorites.Has(v.Address)
+
+					return item
+				}
+				encodeErr error
 			)
 
+			defer apiClient.CloseIdleConnections()
+
+			// JSON lines are written as the nodes are probed, filtered but unsorted
+			if output == outputJSONLines {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				emit = func(item types.Node) {
+					if !filter.Match(&item) || encodeErr != nil {
+						return
+					}
+
+					encodeErr = encoder.Encode(item)
+				}
+			}
+
+			if skipProbe {
+				for i := 0; i < len(items); i++ {
+					emit(newNode(&items[i]))
+				}
+			} else {
+				var stale []nodetypes.Node
+				for i := 0; i < len(items); i++ {
+					if info, ok := cache.Get(items[i].Address, cacheTTL); ok {
+						emit(newNode(&items[i]).WithInfo(info))
+					} else {
+						stale = append(stale, items[i])
+					}
+				}
+
+				p := newProgress(cmd.ErrOrStderr(), len(stale))
+				apiClient.FetchInfos(cmd.Context(), stale, concurrency, func(node *nodetypes.Node, info types.Info, err error) {
+					cache.Put(node.Address, info, err)
+					emit(newNode(node).WithInfo(info))
+					p.add()
+				})
+				p.done()
+			}
+
+			if err = pins.Save(); err != nil {
+				return err
+			}
+			if err = cache.Save(); err != nil {
+				return err
+			}
+			if output == outputJSONLines {
+				return encodeErr
+			}
+
+			nodes = nodes.Filter(filter)
+			if sortBy != "" {
+				nodes.Sort(sortBy, desc, priceDenom(filter))
+			}
+
+			switch output {
+			case outputJSON:
+				return json.NewEncoder(cmd.OutOrStdout()).Encode(nodes)
+			case outputGeoJSON:
+				return json.NewEncoder(cmd.OutOrStdout()).Encode(types.NewGeoJSONFeatureCollection(nodes))
+			case outputKML:
+				return types.WriteKML(cmd.OutOrStdout(), nodes)
+			}
+
+			table := tablewriter.NewWriter(cmd.OutOrStdout())
 			table.SetHeader(header)
-			for i := 0; i < len(items); i++ {
-				group.Add(1)
-				go func(i int) {
-					defer group.Done()
-
-					var (
-						info, _ = fetchNodeInfo(items[i].RemoteURL, timeout)
-						item    = types.NewNodeFromRaw(&items[i]).WithInfo(info)
-					)
-
-					mutex.Lock()
-					defer mutex.Unlock()
-
-					table.Append(
-						[]string{
-							item.Moniker,
-							item.Address,
-							item.GigabytePrices.Raw().String(),
-							item.HourlyPrices.Raw().String(),
-							item.Location.Country,
-							item.Bandwidth.String(),
-							item.Latency.Truncate(1 * time.Millisecond).String(),
-							fmt.Sprintf("%d", item.Peers),
-							fmt.Sprintf("%t", item.Handshake.Enable),
-							nodeTypes[item.Type],
-							item.Version,
-							item.Status,
-						},
-					)
-				}(i)
-			}
-
-			group.Wait()
+
+			for _, item := range nodes {
+				table.Append(nodeRow(item))
+			}
 
 			table.Render()
 			return nil
@@ -262,11 +568,30 @@ func QueryNodes() *cobra.Command {
 	}
 
 	flags.AddQueryFlagsToCmd(cmd)
+	api.AddFlagsToCmd(cmd)
 	flags.AddPaginationFlagsToCmd(cmd, "nodes")
+	clienttypes.AddPaginateFlagsToCmd(cmd)
+
+	cmd.Flags().Lookup(tmcli.OutputFlag).Usage = "output format (text|json|jsonl|geojson|kml)"
 
 	cmd.Flags().Uint64(flagPlanID, 0, "filter with plan id")
 	cmd.Flags().String(flagStatus, "Active", "filter with status (Active|Inactive)")
-	cmd.Flags().Duration(clienttypes.FlagTimeout, 15*time.Second, "time limit for requests made by the HTTP client")
+	cmd.Flags().StringSlice(flagCountry, nil, "filter with countries")
+	cmd.Flags().StringSlice(flagCity, nil, "filter with cities")
+	cmd.Flags().String(flagType, "", "filter with node type (wireguard|v2ray)")
+	cmd.Flags().String(flagMinBandwidth, "", "filter with minimum speed test result for both upload and download (e.g. 10MB)")
+	cmd.Flags().Duration(flagMaxLatency, 0, "filter with maximum latency")
+	cmd.Flags().String(flagMaxGigabytePrice, "", "filter with maximum price per gigabyte in any of the denoms (e.g. 100000udvpn)")
+	cmd.Flags().String(flagMaxHourlyPrice, "", "filter with maximum price per hour in any of the denoms (e.g. 50000udvpn)")
+	cmd.Flags().String(flagMinVersion, "", "filter with minimum node version")
+	cmd.Flags().Bool(flagHandshake, false, "filter with handshake enabled")
+	cmd.Flags().Int(flagMaxPeers, 0, "filter with maximum number of peers")
+	cmd.Flags().String(flagSortBy, "", "sort by the column, followed by :desc for the descending order (e.g. latency, gigabyte-prices:desc)")
+	cmd.Flags().Bool(clienttypes.FlagTLSStrict, false, "fail if the certificate of a node does not match the pinned one")
+	cmd.Flags().Int(flagConcurrency, 16, "number of nodes probed at the same time")
+	cmd.Flags().Bool(flagSkipProbe, false, "list the on-chain information only, without probing the nodes")
+	cmd.Flags().Duration(clienttypes.FlagCacheTTL, 0, "use the cached information of the nodes probed within this time instead of probing them")
+	cmd.Flags().Bool(flagShowBlocked, false, "include the blocked nodes")
 
 	return cmd
 }
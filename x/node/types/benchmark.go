@@ -0,0 +1,125 @@
+package types
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+const (
+	BenchmarksFileName = "benchmarks.json"
+
+	// maxBenchmarks is the number of the latest benchmarks kept for each node.
+	maxBenchmarks = 10
+)
+
+// Benchmark is the TCP round trip times of a node and its throughput in bytes/s.
+type Benchmark struct {
+	Address    string        `json:"address"`
+	At         time.Time     `json:"at"`
+	Samples    int           `json:"samples"`
+	Lost       int           `json:"lost"`
+	Min        time.Duration `json:"min"`
+	Avg        time.Duration `json:"avg"`
+	Jitter     time.Duration `json:"jitter"`
+	Throughput int64         `json:"throughput,omitempty"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// NewBenchmark computes the statistics of the samples, where failed ones are zero.
+func NewBenchmark(address string, rtts []time.Duration) Benchmark {
+	b := Benchmark{
+		Address: address,
+		At:      time.Now().UTC(),
+		Samples: len(rtts),
+	}
+
+	var (
+		sum      time.Duration
+		diffs    time.Duration
+		previous time.Duration
+		count    int
+	)
+
+	for _, rtt := range rtts {
+		if rtt == 0 {
+			b.Lost++
+			continue
+		}
+		if count == 0 || rtt < b.Min {
+			b.Min = rtt
+		}
+		if count > 0 {
+			diff := rtt - previous
+			if diff < 0 {
+				diff = -diff
+			}
+
+			diffs += diff
+		}
+
+		sum, previous = sum+rtt, rtt
+		count++
+	}
+
+	if count > 0 {
+		b.Avg = sum / time.Duration(count)
+	}
+	if count > 1 {
+		b.Jitter = diffs / time.Duration(count-1)
+	}
+
+	return b
+}
+
+// BenchmarkStore keeps the latest benchmarks of each node in a file in the
+// home directory.
+type BenchmarkStore struct {
+	*clienttypes.JSONStore[[]Benchmark]
+}
+
+func NewBenchmarkStore(home string) *BenchmarkStore {
+	return &BenchmarkStore{
+		JSONStore: clienttypes.NewJSONStore[[]Benchmark](filepath.Join(home, BenchmarksFileName), nil),
+	}
+}
+
+func (s *BenchmarkStore) Add(item Benchmark) {
+	s.Update(func(m map[string][]Benchmark) bool {
+		items := append(m[item.Address], item)
+		if len(items) > maxBenchmarks {
+			items = items[len(items)-maxBenchmarks:]
+		}
+
+		m[item.Address] = items
+		return true
+	})
+}
+
+// Latest returns the latest benchmark of each node, the fastest first.
+func (s *BenchmarkStore) Latest() (items []Benchmark) {
+	s.View(func(m map[string][]Benchmark) {
+		items = make([]Benchmark, 0, len(m))
+		for _, v := range m {
+			if len(v) > 0 {
+				items = append(items, v[len(v)-1])
+			}
+		}
+	})
+
+	sort.Slice(items, func(i, j int) bool {
+		x, y := items[i], items[j]
+		if (x.Avg == 0) != (y.Avg == 0) {
+			return x.Avg != 0
+		}
+		if x.Throughput != y.Throughput {
+			return x.Throughput > y.Throughput
+		}
+
+		return x.Avg < y.Avg
+	})
+
+	return items
+}
@@ -0,0 +1,159 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+const (
+	CacheFileName = "nodes.cache.json"
+
+	// maxProbes is the number of the latest probes kept for each node.
+	maxProbes = 20
+)
+
+type Probe struct {
+	At      time.Time     `json:"at"`
+	Latency time.Duration `json:"latency"`
+	Error   string        `json:"error,omitempty"`
+}
+
+type CacheEntry struct {
+	Info      Info      `json:"info"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Probes    []Probe   `json:"probes"`
+}
+
+// Reachability returns the number of the successful probes and the number of
+// all the probes kept.
+func (e *CacheEntry) Reachability() (ok, total int) {
+	for _, item := range e.Probes {
+		if item.Error == "" {
+			ok++
+		}
+	}
+
+	return ok, len(e.Probes)
+}
+
+// AverageLatency returns the average latency of the successful probes.
+func (e *CacheEntry) AverageLatency() time.Duration {
+	var (
+		sum   time.Duration
+		count int
+	)
+
+	for _, item := range e.Probes {
+		if item.Error == "" {
+			sum += item.Latency
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return sum / time.Duration(count)
+}
+
+// Cache keeps the information reported by the nodes, along with the history
+// of the probes, in a file in the home directory.
+type Cache struct {
+	*clienttypes.JSONStore[*CacheEntry]
+}
+
+func NewCache(home string) *Cache {
+	return &Cache{
+		JSONStore: clienttypes.NewJSONStore[*CacheEntry](filepath.Join(home, CacheFileName), nil),
+	}
+}
+
+func (c *Cache) Load() error {
+	err := c.JSONStore.Load()
+	if errors.Is(err, clienttypes.ErrorInvalidStore) {
+		return fmt.Errorf("%w; run cache clear", err)
+	}
+
+	return err
+}
+
+// Get returns the information of the node if it was fetched within the ttl.
+func (c *Cache) Get(address string, ttl time.Duration) (info Info, ok bool) {
+	c.View(func(items map[string]*CacheEntry) {
+		item, found := items[address]
+		if !found || item.FetchedAt.IsZero() || time.Since(item.FetchedAt) > ttl {
+			return
+		}
+
+		info, ok = item.Info, true
+	})
+
+	return info, ok
+}
+
+// Put records a probe of the node, keeping the information if it succeeded.
+func (c *Cache) Put(address string, info Info, err error) {
+	c.Update(func(items map[string]*CacheEntry) bool {
+		item, ok := items[address]
+		if !ok {
+			item = &CacheEntry{}
+			items[address] = item
+		}
+
+		probe := Probe{
+			At:      time.Now().UTC(),
+			Latency: info.Latency,
+		}
+
+		if err != nil {
+			probe.Error = err.Error()
+		} else {
+			item.Info, item.FetchedAt = info, probe.At
+		}
+
+		item.Probes = append(item.Probes, probe)
+		if len(item.Probes) > maxProbes {
+			item.Probes = item.Probes[len(item.Probes)-maxProbes:]
+		}
+
+		return true
+	})
+}
+
+func (c *Cache) Entry(address string) (entry CacheEntry, ok bool) {
+	c.View(func(items map[string]*CacheEntry) {
+		if item, found := items[address]; found {
+			entry, ok = *item, true
+		}
+	})
+
+	return entry, ok
+}
+
+func (c *Cache) Addresses() (addresses []string) {
+	c.View(func(items map[string]*CacheEntry) {
+		addresses = make([]string, 0, len(items))
+		for address := range items {
+			addresses = append(addresses, address)
+		}
+	})
+
+	sort.Strings(addresses)
+	return addresses
+}
+
+func (c *Cache) Clear() {
+	c.Update(func(items map[string]*CacheEntry) bool {
+		for address := range items {
+			delete(items, address)
+		}
+
+		return true
+	})
+}
@@ -0,0 +1,143 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+// Filter selects nodes; an unprobed node fails any filter on reported info.
+type Filter struct {
+	Countries        []string
+	Cities           []string
+	Type             uint64
+	MinBandwidth     int64
+	MaxLatency       time.Duration
+	MaxGigabytePrice sdk.Coins
+	MaxHourlyPrice   sdk.Coins
+	MinVersion       string
+	Handshake        bool
+	MaxPeers         int
+}
+
+func containsFold(items []string, s string) bool {
+	for _, item := range items {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchPrice returns whether the prices have a price in any of the denoms of
+// the limits which is within the limit.
+func matchPrice(prices clienttypes.Coins, limits sdk.Coins) bool {
+	for _, price := range prices {
+		limit := limits.AmountOf(price.Denom)
+		if limit.IsPositive() && limit.GTE(sdk.NewInt(price.Value)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CompareVersions compares two versions of the form v1.2.3, ignoring any
+// pre-release or build suffix.
+func CompareVersions(x, y string) int {
+	parse := func(s string) []int {
+		s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+		if i := strings.IndexAny(s, "-+"); i >= 0 {
+			s = s[:i]
+		}
+
+		var items []int
+		for _, v := range strings.Split(s, ".") {
+			n, _ := strconv.Atoi(v)
+			items = append(items, n)
+		}
+
+		return items
+	}
+
+	a, b := parse(x), parse(y)
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var m, n int
+		if i < len(a) {
+			m = a[i]
+		}
+		if i < len(b) {
+			n = b[i]
+		}
+		if m != n {
+			if m < n {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// NeedsInfo returns whether the filter depends on the information reported
+// by the nodes.
+func (f *Filter) NeedsInfo() bool {
+	return len(f.Countries) > 0 || len(f.Cities) > 0 || f.Type != 0 || f.MinBandwidth > 0 ||
+		f.MaxLatency > 0 || f.MinVersion != "" || f.Handshake || f.MaxPeers > 0
+}
+
+func (f *Filter) Match(n *Node) bool {
+	if f.NeedsInfo() && n.Info.Address == "" {
+		return false
+	}
+	if len(f.Countries) > 0 && !containsFold(f.Countries, n.Location.Country) {
+		return false
+	}
+	if len(f.Cities) > 0 && !containsFold(f.Cities, n.Location.City) {
+		return false
+	}
+	if f.Type != 0 && n.Type != f.Type {
+		return false
+	}
+	if f.MinBandwidth > 0 && (n.Bandwidth.Upload < f.MinBandwidth || n.Bandwidth.Download < f.MinBandwidth) {
+		return false
+	}
+	if f.MaxLatency > 0 && n.Latency > f.MaxLatency {
+		return false
+	}
+	if len(f.MaxGigabytePrice) > 0 && !matchPrice(n.GigabytePrices, f.MaxGigabytePrice) {
+		return false
+	}
+	if len(f.MaxHourlyPrice) > 0 && !matchPrice(n.HourlyPrices, f.MaxHourlyPrice) {
+		return false
+	}
+	if f.MinVersion != "" && CompareVersions(n.Version, f.MinVersion) < 0 {
+		return false
+	}
+	if f.Handshake && !n.Handshake.Enable {
+		return false
+	}
+	if f.MaxPeers > 0 && n.Peers > f.MaxPeers {
+		return false
+	}
+
+	return true
+}
+
+func (n Nodes) Filter(f *Filter) Nodes {
+	items := make(Nodes, 0, len(n))
+	for i := 0; i < len(n); i++ {
+		if f.Match(&n[i]) {
+			items = append(items, n[i])
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		x, y string
+		want int
+	}{
+		{"v0.7.1", "0.7.1", 0},
+		{"0.7.9", "0.7.10", -1},
+		{"0.8", "0.7.9", 1},
+		{"v0.7.1-rc1", "0.7.1", 0},
+	}
+
+	for _, tc := range tests {
+		if got := CompareVersions(tc.x, tc.y); got != tc.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tc.x, tc.y, got, tc.want)
+		}
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	var (
+		probed = Node{
+			Info: Info{
+				Address:   "sentnode1a",
+				Bandwidth: clienttypes.Bandwidth{Upload: 20, Download: 50},
+				Latency:   100 * time.Millisecond,
+				Location:  Location{Country: "Germany"},
+			},
+			Address:        "sentnode1a",
+			GigabytePrices: clienttypes.Coins{{Denom: "udvpn", Value: 100}},
+		}
+		unprobed = Node{
+			Address:        "sentnode1b",
+			GigabytePrices: clienttypes.Coins{{Denom: "udvpn", Value: 100}},
+		}
+		maxPrice = func(denom string, v int64) sdk.Coins { return sdk.NewCoins(sdk.NewInt64Coin(denom, v)) }
+	)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		node   Node
+		want   bool
+	}{
+		{"country ignores case", Filter{Countries: []string{"germany"}}, probed, true},
+		{"bandwidth of both directions", Filter{MinBandwidth: 30}, probed, false},
+		{"price within the limit", Filter{MaxGigabytePrice: maxPrice("udvpn", 100)}, probed, true},
+		{"price in another denom", Filter{MaxGigabytePrice: maxPrice("uatom", 1000)}, probed, false},
+		{"price of an unprobed node", Filter{MaxGigabytePrice: maxPrice("udvpn", 100)}, unprobed, true},
+		{"reported info of an unprobed node", Filter{MaxLatency: time.Second}, unprobed, false},
+	}
+
+	for _, tc := range tests {
+		if got := tc.filter.Match(&tc.node); got != tc.want {
+			t.Errorf("%s: Match() = %t, want %t", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package types
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+type (
+	GeoJSONGeometry struct {
+		Type        string    `json:"type"`
+		Coordinates []float64 `json:"coordinates"`
+	}
+	GeoJSONProperties struct {
+		Moniker        string `json:"moniker"`
+		Address        string `json:"address"`
+		Type           string `json:"type"`
+		GigabytePrices string `json:"gigabyte_prices"`
+		HourlyPrices   string `json:"hourly_prices"`
+		Latency        int64  `json:"latency_ms"`
+		Status         string `json:"status"`
+		Country        string `json:"country"`
+		City           string `json:"city"`
+		Favorite       bool   `json:"favorite,omitempty"`
+	}
+	GeoJSONFeature struct {
+		Type       string            `json:"type"`
+		Geometry   GeoJSONGeometry   `json:"geometry"`
+		Properties GeoJSONProperties `json:"properties"`
+	}
+	GeoJSONFeatureCollection struct {
+		Type     string           `json:"type"`
+		Features []GeoJSONFeature `json:"features"`
+	}
+)
+
+func newGeoJSONProperties(n *Node) GeoJSONProperties {
+	return GeoJSONProperties{
+		Moniker:        n.Moniker,
+		Address:        n.Address,
+		Type:           clienttypes.ServiceName(n.Type),
+		GigabytePrices: n.GigabytePrices.Raw().String(),
+		HourlyPrices:   n.HourlyPrices.Raw().String(),
+		Latency:        n.Latency.Milliseconds(),
+		Status:         n.Status,
+		Country:        n.Location.Country,
+		City:           n.Location.City,
+		Favorite:       n.Favorite,
+	}
+}
+
+// NewGeoJSONFeatureCollection returns a point feature of each of the nodes
+// which reported a location, the probed ones.
+func NewGeoJSONFeatureCollection(nodes Nodes) GeoJSONFeatureCollection {
+	collection := GeoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: []GeoJSONFeature{},
+	}
+
+	for i := range nodes {
+		if nodes[i].Info.Address == "" {
+			continue
+		}
+
+		collection.Features = append(collection.Features, GeoJSONFeature{
+			Type: "Feature",
+			Geometry: GeoJSONGeometry{
+				Type:        "Point",
+				Coordinates: []float64{nodes[i].Location.Longitude, nodes[i].Location.Latitude},
+			},
+			Properties: newGeoJSONProperties(&nodes[i]),
+		})
+	}
+
+	return collection
+}
+
+type (
+	kmlData struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value"`
+	}
+	kmlPlacemark struct {
+		Name        string    `xml:"name"`
+		Description string    `xml:"description"`
+		Data        []kmlData `xml:"ExtendedData>Data"`
+		Coordinates string    `xml:"Point>coordinates"`
+	}
+	kmlDocument struct {
+		XMLName    xml.Name       `xml:"http://www.opengis.net/kml/2.2 kml"`
+		Name       string         `xml:"Document>name"`
+		Placemarks []kmlPlacemark `xml:"Document>Placemark"`
+	}
+)
+
+// WriteKML writes a placemark of each of the nodes which reported a location,
+// the probed ones.
+func WriteKML(w io.Writer, nodes Nodes) error {
+	doc := kmlDocument{
+		Name: "Sentinel nodes",
+	}
+
+	for i := range nodes {
+		if nodes[i].Info.Address == "" {
+			continue
+		}
+
+		p := newGeoJSONProperties(&nodes[i])
+		doc.Placemarks = append(doc.Placemarks, kmlPlacemark{
+			Name:        p.Moniker,
+			Description: fmt.Sprintf("%s, %s (%s)", p.City, p.Country, p.Address),
+			Data: []kmlData{
+				{Name: "address", Value: p.Address},
+				{Name: "type", Value: p.Type},
+				{Name: "gigabyte_prices", Value: p.GigabytePrices},
+				{Name: "hourly_prices", Value: p.HourlyPrices},
+				{Name: "latency_ms", Value: strconv.FormatInt(p.Latency, 10)},
+				{Name: "status", Value: p.Status},
+				{Name: "favorite", Value: strconv.FormatBool(p.Favorite)},
+			},
+			Coordinates: fmt.Sprintf("%f,%f", nodes[i].Location.Longitude, nodes[i].Location.Latitude),
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
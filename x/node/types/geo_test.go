@@ -0,0 +1,55 @@
+package types
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func geoTestNodes() Nodes {
+	return Nodes{
+		{
+			Info: Info{
+				Address:  "sentnode1a",
+				Moniker:  "a & b",
+				Location: Location{City: "Berlin", Country: "Germany", Latitude: 52.52, Longitude: 13.405},
+				Type:     testServiceType,
+			},
+			Address: "sentnode1a",
+		},
+		{Address: "sentnode1b"},
+	}
+}
+
+func TestNewGeoJSONFeatureCollection(t *testing.T) {
+	features := NewGeoJSONFeatureCollection(geoTestNodes()).Features
+	if len(features) != 1 {
+		t.Fatalf("features = %d, want the probed node only", len(features))
+	}
+	if want := []float64{13.405, 52.52}; !reflect.DeepEqual(features[0].Geometry.Coordinates, want) {
+		t.Errorf("coordinates = %v, want longitude and latitude %v", features[0].Geometry.Coordinates, want)
+	}
+
+	if features = NewGeoJSONFeatureCollection(nil).Features; features == nil {
+		t.Error("features of no nodes are null")
+	}
+}
+
+func TestWriteKML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteKML(&buf, geoTestNodes()); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc kmlDocument
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid document: %v", err)
+	}
+	if len(doc.Placemarks) != 1 {
+		t.Fatalf("placemarks = %d, want the probed node only", len(doc.Placemarks))
+	}
+	if p := doc.Placemarks[0]; p.Name != "a & b" || p.Coordinates != "13.405000,52.520000" {
+		t.Errorf("placemark = %+v", p)
+	}
+}
@@ -16,6 +16,7 @@ type Node struct {
 	RemoteURL      string            `json:"remote_url"`
 	Status         string            `json:"status"`
 	StatusAt       time.Time         `json:"status_at"`
+	Favorite       bool              `json:"favorite,omitempty"`
 }
 
 func (n Node) WithInfo(v Info) Node { n.Info = v; return n }
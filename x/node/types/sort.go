@@ -0,0 +1,117 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+const (
+	DefaultPriceDenom = "udvpn"
+)
+
+// priceOf returns the amount of the denom in the prices, or -1 if there is none.
+func priceOf(prices clienttypes.Coins, denom string) int64 {
+	for _, price := range prices {
+		if price.Denom == denom {
+			return price.Value
+		}
+	}
+
+	return -1
+}
+
+type nodeLess func(x, y *Node, denom string) bool
+
+var (
+	sortColumns = map[string]nodeLess{
+		"moniker": func(x, y *Node, _ string) bool {
+			return strings.ToLower(x.Moniker) < strings.ToLower(y.Moniker)
+		},
+		"address": func(x, y *Node, _ string) bool { return x.Address < y.Address },
+		"gigabyte-prices": func(x, y *Node, denom string) bool {
+			return priceOf(x.GigabytePrices, denom) < priceOf(y.GigabytePrices, denom)
+		},
+		"hourly-prices": func(x, y *Node, denom string) bool {
+			return priceOf(x.HourlyPrices, denom) < priceOf(y.HourlyPrices, denom)
+		},
+		"country": func(x, y *Node, _ string) bool {
+			if x.Location.Country != y.Location.Country {
+				return x.Location.Country < y.Location.Country
+			}
+
+			return x.Location.City < y.Location.City
+		},
+		"city": func(x, y *Node, _ string) bool { return x.Location.City < y.Location.City },
+		"speed-test": func(x, y *Node, _ string) bool {
+			return x.Bandwidth.Upload+x.Bandwidth.Download < y.Bandwidth.Upload+y.Bandwidth.Download
+		},
+		"latency":   func(x, y *Node, _ string) bool { return x.Latency < y.Latency },
+		"peers":     func(x, y *Node, _ string) bool { return x.Peers < y.Peers },
+		"handshake": func(x, y *Node, _ string) bool { return !x.Handshake.Enable && y.Handshake.Enable },
+		"type":      func(x, y *Node, _ string) bool { return x.Type < y.Type },
+		"version":   func(x, y *Node, _ string) bool { return CompareVersions(x.Version, y.Version) < 0 },
+		"status":    func(x, y *Node, _ string) bool { return x.Status < y.Status },
+	}
+
+	// sortMissing reports whether a node has no value in the column, which
+	// is sorted last in either order.
+	sortMissing = map[string]func(n *Node, denom string) bool{
+		"gigabyte-prices": func(n *Node, denom string) bool { return priceOf(n.GigabytePrices, denom) < 0 },
+		"hourly-prices":   func(n *Node, denom string) bool { return priceOf(n.HourlyPrices, denom) < 0 },
+		// Nodes which could not be probed have no latency
+		"latency": func(n *Node, _ string) bool { return n.Latency == 0 },
+	}
+)
+
+func SortColumns() []string {
+	items := make([]string, 0, len(sortColumns))
+	for name := range sortColumns {
+		items = append(items, name)
+	}
+
+	sort.Strings(items)
+	return items
+}
+
+// ParseSortBy parses a column name, followed by :desc for the descending order.
+func ParseSortBy(s string) (column string, desc bool, err error) {
+	column = strings.ToLower(strings.TrimSpace(s))
+	if v := strings.TrimSuffix(column, ":desc"); v != column {
+		column, desc = v, true
+	} else {
+		column = strings.TrimSuffix(column, ":asc")
+	}
+
+	if _, ok := sortColumns[column]; !ok {
+		return "", false, fmt.Errorf("invalid sort column %s, expected one of %s", s, strings.Join(SortColumns(), ", "))
+	}
+
+	return column, desc, nil
+}
+
+// Sort sorts the nodes by the column. Prices are compared in the denom, and
+// nodes without a price in it, or without a latency, are sorted last.
+func (n Nodes) Sort(column string, desc bool, denom string) {
+	less, ok := sortColumns[column]
+	if !ok {
+		return
+	}
+
+	missing := sortMissing[column]
+
+	sort.SliceStable(n, func(i, j int) bool {
+		if missing != nil {
+			if x, y := missing(&n[i], denom), missing(&n[j], denom); x || y {
+				return !x && y
+			}
+		}
+		if desc {
+			return less(&n[j], &n[i], denom)
+		}
+
+		return less(&n[i], &n[j], denom)
+	})
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+func TestParseSortBy(t *testing.T) {
+	if column, desc, err := ParseSortBy(" Latency:DESC "); err != nil || column != "latency" || !desc {
+		t.Errorf("ParseSortBy() = %q, %t, %v, want latency descending", column, desc, err)
+	}
+	if _, _, err := ParseSortBy("speed"); err == nil {
+		t.Error("ParseSortBy() of an unknown column did not fail")
+	}
+}
+
+func TestNodesSort(t *testing.T) {
+	nodes := Nodes{
+		{Address: "a", Info: Info{Latency: 30 * time.Millisecond}, GigabytePrices: clienttypes.Coins{{Denom: "udvpn", Value: 300}}},
+		{Address: "b", GigabytePrices: clienttypes.Coins{{Denom: "uatom", Value: 1}}},
+		{Address: "c", Info: Info{Latency: 10 * time.Millisecond}, GigabytePrices: clienttypes.Coins{{Denom: "udvpn", Value: 100}}},
+	}
+
+	addresses := func(items Nodes) (s string) {
+		for _, item := range items {
+			s += item.Address
+		}
+
+		return s
+	}
+
+	tests := []struct {
+		column string
+		desc   bool
+		want   string
+	}{
+		{"gigabyte-prices", false, "cab"},
+		{"gigabyte-prices", true, "acb"},
+		{"latency", false, "cab"},
+		{"latency", true, "acb"},
+	}
+
+	for _, tc := range tests {
+		items := append(Nodes{}, nodes...)
+		if items.Sort(tc.column, tc.desc, "udvpn"); addresses(items) != tc.want {
+			t.Errorf("Sort(%s, desc %t) = %s, want %s", tc.column, tc.desc, addresses(items), tc.want)
+		}
+	}
+}
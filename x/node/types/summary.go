@@ -0,0 +1,139 @@
+package types
+
+import (
+	"sort"
+	"time"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+// Summary aggregates the nodes of a country, or of a city if City is set.
+type Summary struct {
+	Country             string         `json:"country"`
+	City                string         `json:"city,omitempty"`
+	Nodes               int            `json:"nodes"`
+	Types               map[string]int `json:"types"`
+	Online              int            `json:"online"`
+	Unreachable         int            `json:"unreachable"`
+	MedianGigabytePrice int64          `json:"median_gigabyte_price"`
+	MedianHourlyPrice   int64          `json:"median_hourly_price"`
+	MedianLatency       time.Duration  `json:"median_latency"`
+	Peers               int            `json:"peers"`
+}
+
+// OnlineRatio returns the share of the nodes which were reachable.
+func (s *Summary) OnlineRatio() float64 {
+	if s.Nodes == 0 {
+		return 0
+	}
+
+	return float64(s.Online) / float64(s.Nodes)
+}
+
+func amountOf(coins clienttypes.Coins, denom string) (int64, bool) {
+	for _, item := range coins {
+		if item.Denom == denom {
+			return item.Value, true
+		}
+	}
+
+	return 0, false
+}
+
+func median[T int64 | time.Duration](items []T) T {
+	if len(items) == 0 {
+		return 0
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i] < items[j] })
+
+	n := len(items)
+	if n%2 == 1 {
+		return items[n/2]
+	}
+
+	return (items[n/2-1] + items[n/2]) / 2
+}
+
+// Summarize groups the nodes by country, and by city if byCity is set.
+func Summarize(nodes Nodes, online map[string]bool, byCity bool, denom string) []Summary {
+	type group struct {
+		summary   *Summary
+		gigabyte  []int64
+		hourly    []int64
+		latencies []time.Duration
+	}
+
+	var (
+		groups = make(map[[2]string]*group)
+		keys   [][2]string
+	)
+
+	for i := range nodes {
+		n := &nodes[i]
+
+		key := [2]string{n.Location.Country, ""}
+		if byCity {
+			key[1] = n.Location.City
+		}
+
+		g, ok := groups[key]
+		if !ok {
+			g = &group{
+				summary: &Summary{
+					Country: key[0],
+					City:    key[1],
+					Types:   make(map[string]int),
+				},
+			}
+
+			groups[key] = g
+			keys = append(keys, key)
+		}
+
+		s := g.summary
+		s.Nodes++
+
+		if n.Type != 0 {
+			s.Types[clienttypes.ServiceName(n.Type)]++
+		}
+		if v, ok := amountOf(n.GigabytePrices, denom); ok {
+			g.gigabyte = append(g.gigabyte, v)
+		}
+		if v, ok := amountOf(n.HourlyPrices, denom); ok {
+			g.hourly = append(g.hourly, v)
+		}
+
+		if !online[n.Address] {
+			s.Unreachable++
+			continue
+		}
+
+		s.Online++
+		s.Peers += n.Peers
+		g.latencies = append(g.latencies, n.Latency)
+	}
+
+	items := make([]Summary, 0, len(keys))
+	for _, key := range keys {
+		g := groups[key]
+		g.summary.MedianGigabytePrice = median(g.gigabyte)
+		g.summary.MedianHourlyPrice = median(g.hourly)
+		g.summary.MedianLatency = median(g.latencies)
+
+		items = append(items, *g.summary)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Nodes != items[j].Nodes {
+			return items[i].Nodes > items[j].Nodes
+		}
+		if items[i].Country != items[j].Country {
+			return items[i].Country < items[j].Country
+		}
+
+		return items[i].City < items[j].City
+	})
+
+	return items
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+func TestMedian(t *testing.T) {
+	if got := median([]int64{9, 1, 5}); got != 5 {
+		t.Errorf("median of odd = %d, want 5", got)
+	}
+	if got := median([]int64{4, 1, 3, 2}); got != 2 {
+		t.Errorf("median of even = %d, want 2", got)
+	}
+	if got := median[int64](nil); got != 0 {
+		t.Errorf("median of none = %d, want 0", got)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	node := func(address, city string, price int64, latency time.Duration) Node {
+		return Node{
+			Info: Info{
+				Address:  address,
+				Latency:  latency,
+				Location: Location{Country: "Germany", City: city},
+				Peers:    1,
+				Type:     testServiceType,
+			},
+			Address:        address,
+			GigabytePrices: clienttypes.Coins{{Denom: "udvpn", Value: price}},
+		}
+	}
+
+	nodes := Nodes{
+		node("a", "Munich", 200, 20*time.Millisecond),
+		node("b", "Berlin", 100, 10*time.Millisecond),
+		node("c", "Berlin", 300, 0),
+	}
+
+	want := []Summary{
+		{
+			Country: "Germany", City: "Berlin", Nodes: 2, Types: map[string]int{"test": 2},
+			Online: 1, Unreachable: 1, MedianGigabytePrice: 200, MedianLatency: 10 * time.Millisecond, Peers: 1,
+		},
+		{
+			Country: "Germany", City: "Munich", Nodes: 1, Types: map[string]int{"test": 1},
+			Online: 1, MedianGigabytePrice: 200, MedianLatency: 20 * time.Millisecond, Peers: 1,
+		},
+	}
+
+	got := Summarize(nodes, map[string]bool{"a": true, "b": true}, true, "udvpn")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Summarize() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	nodetypes "github.com/sentinel-official/hub/x/node/types"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+type Mismatch struct {
+	Field    string `json:"field"`
+	OnChain  string `json:"on_chain"`
+	Reported string `json:"reported"`
+	Fatal    bool   `json:"fatal"`
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("%s: on chain %q, reported %q", m.Field, m.OnChain, m.Reported)
+}
+
+type Mismatches []Mismatch
+
+func (m Mismatches) Fatal() Mismatches {
+	var items Mismatches
+	for _, item := range m {
+		if item.Fatal {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func verifyPrices(field string, onChain sdk.Coins, reported string) *Mismatch {
+	coins, err := sdk.ParseCoinsNormalized(reported)
+	if err == nil && coins.String() == onChain.String() {
+		return nil
+	}
+
+	return &Mismatch{
+		Field:    field,
+		OnChain:  onChain.String(),
+		Reported: reported,
+	}
+}
+
+// Verify cross-checks the information reported by the node with the chain.
+func Verify(node *nodetypes.Node, info Info) Mismatches {
+	var items Mismatches
+	if info.Address != node.Address {
+		items = append(items, Mismatch{
+			Field:    "address",
+			OnChain:  node.Address,
+			Reported: info.Address,
+			Fatal:    true,
+		})
+	}
+	if _, err := clienttypes.GetService(info.Type); err != nil {
+		items = append(items, Mismatch{
+			Field:    "type",
+			Reported: fmt.Sprintf("%d", info.Type),
+			Fatal:    true,
+		})
+	}
+	if item := verifyPrices("gigabyte_prices", node.GigabytePrices, info.GigabytePrices); item != nil {
+		items = append(items, *item)
+	}
+	if item := verifyPrices("hourly_prices", node.HourlyPrices, info.HourlyPrices); item != nil {
+		items = append(items, *item)
+	}
+
+	return items
+}
@@ -0,0 +1,47 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	nodetypes "github.com/sentinel-official/hub/x/node/types"
+
+	clienttypes "github.com/sentinel-official/cli-client/types"
+)
+
+const (
+	testServiceType = 99
+)
+
+func init() {
+	clienttypes.RegisterService(clienttypes.ServiceDefinition{Type: testServiceType, Name: "test"})
+}
+
+func TestVerify(t *testing.T) {
+	node := &nodetypes.Node{
+		Address:        "sentnode1a",
+		GigabytePrices: sdk.NewCoins(sdk.NewInt64Coin("udvpn", 100)),
+		HourlyPrices:   sdk.NewCoins(sdk.NewInt64Coin("udvpn", 10), sdk.NewInt64Coin("uatom", 1)),
+	}
+
+	info := Info{
+		Address:        "sentnode1a",
+		GigabytePrices: "100udvpn",
+		HourlyPrices:   "10udvpn,1uatom",
+		Type:           testServiceType,
+	}
+
+	if items := Verify(node, info); len(items) != 0 {
+		t.Errorf("matching node: %v", items)
+	}
+
+	info.GigabytePrices = "200udvpn"
+	if items := Verify(node, info); len(items) != 1 || items[0].Field != "gigabyte_prices" || len(items.Fatal()) != 0 {
+		t.Errorf("changed price: %v, want a non-fatal gigabyte_prices mismatch", items)
+	}
+
+	info.Address, info.Type = "sentnode1b", 0
+	if items := Verify(node, info).Fatal(); len(items) != 2 || items[0].Field != "address" || items[1].Field != "type" {
+		t.Errorf("other node: %v, want fatal address and type mismatches", items)
+	}
+}
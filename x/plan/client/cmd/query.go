@@ -7,11 +7,13 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/olekukonko/tablewriter"
 	hubtypes "github.com/sentinel-official/hub/types"
 	plantypes "github.com/sentinel-official/hub/x/plan/types"
 	"github.com/spf13/cobra"
 
+	clienttypes "github.com/sentinel-official/cli-client/types"
 	"github.com/sentinel-official/cli-client/x/plan/types"
 )
 
@@ -106,6 +108,11 @@ func QueryPlans() *cobra.Command {
 				return err
 			}
 
+			paginate, err := clienttypes.NewPaginateOptionsFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
 			var (
 				items  types.Plans
 				qsc    = plantypes.NewQueryServiceClient(ctx)
@@ -118,32 +125,50 @@ func QueryPlans() *cobra.Command {
 					return err
 				}
 
-				result, err := qsc.QueryPlansForProvider(
-					context.Background(),
-					plantypes.NewQueryPlansForProviderRequest(
-						address,
-						status,
-						pagination,
-					),
+				plans, err := clienttypes.Paginate(cmd.Context(), pagination, paginate,
+					func(pagination *query.PageRequest) ([]plantypes.Plan, *query.PageResponse, error) {
+						result, err := qsc.QueryPlansForProvider(
+							context.Background(),
+							plantypes.NewQueryPlansForProviderRequest(
+								address,
+								status,
+								pagination,
+							),
+						)
+						if err != nil {
+							return nil, nil, err
+						}
+
+						return result.Plans, result.Pagination, nil
+					},
 				)
 				if err != nil {
 					return err
 				}
 
-				items = append(items, types.NewPlansFromRaw(result.Plans)...)
+				items = append(items, types.NewPlansFromRaw(plans)...)
 			} else {
-				result, err := qsc.QueryPlans(
-					context.Background(),
-					plantypes.NewQueryPlansRequest(
-						status,
-						pagination,
-					),
+				plans, err := clienttypes.Paginate(cmd.Context(), pagination, paginate,
+					func(pagination *query.PageRequest) ([]plantypes.Plan, *query.PageResponse, error) {
+						result, err := qsc.QueryPlans(
+							context.Background(),
+							plantypes.NewQueryPlansRequest(
+								status,
+								pagination,
+							),
+						)
+						if err != nil {
+							return nil, nil, err
+						}
+
+						return result.Plans, result.Pagination, nil
+					},
 				)
 				if err != nil {
 					return err
 				}
 
-				items = append(items, types.NewPlansFromRaw(result.Plans)...)
+				items = append(items, types.NewPlansFromRaw(plans)...)
 			}
 
 			table := tablewriter.NewWriter(cmd.OutOrStdout())
@@ -169,6 +194,7 @@ func QueryPlans() *cobra.Command {
 
 	flags.AddQueryFlagsToCmd(cmd)
 	flags.AddPaginationFlagsToCmd(cmd, "plans")
+	clienttypes.AddPaginateFlagsToCmd(cmd)
 
 	cmd.Flags().String(flagProvider, "", "filter with provider address")
 	cmd.Flags().String(flagStatus, "Active", "filter with status")
@@ -5,11 +5,13 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/olekukonko/tablewriter"
 	hubtypes "github.com/sentinel-official/hub/types"
 	providertypes "github.com/sentinel-official/hub/x/provider/types"
 	"github.com/spf13/cobra"
 
+	clienttypes "github.com/sentinel-official/cli-client/types"
 	"github.com/sentinel-official/cli-client/x/provider/types"
 )
 
@@ -95,23 +97,37 @@ func QueryProviders() *cobra.Command {
 				return err
 			}
 
+			paginate, err := clienttypes.NewPaginateOptionsFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
 			var (
 				qsc = providertypes.NewQueryServiceClient(ctx)
 			)
 
-			result, err := qsc.QueryProviders(
-				context.Background(),
-				providertypes.NewQueryProvidersRequest(
-					hubtypes.StatusFromString(s),
-					pagination,
-				),
+			providers, err := clienttypes.Paginate(cmd.Context(), pagination, paginate,
+				func(pagination *query.PageRequest) ([]providertypes.Provider, *query.PageResponse, error) {
+					result, err := qsc.QueryProviders(
+						context.Background(),
+						providertypes.NewQueryProvidersRequest(
+							hubtypes.StatusFromString(s),
+							pagination,
+						),
+					)
+					if err != nil {
+						return nil, nil, err
+					}
+
+					return result.Providers, result.Pagination, nil
+				},
 			)
 			if err != nil {
 				return err
 			}
 
 			var (
-				items = types.NewProvidersFromRaw(result.Providers)
+				items = types.NewProvidersFromRaw(providers)
 				table = tablewriter.NewWriter(cmd.OutOrStdout())
 			)
 
@@ -134,6 +150,7 @@ func QueryProviders() *cobra.Command {
 
 	flags.AddQueryFlagsToCmd(cmd)
 	flags.AddPaginationFlagsToCmd(cmd, "providers")
+	clienttypes.AddPaginateFlagsToCmd(cmd)
 	cmd.Flags().String(flagStatus, "active", "filter with status (active|inactive)")
 
 	return cmd
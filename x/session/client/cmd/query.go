@@ -9,10 +9,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/olekukonko/tablewriter"
 	sessiontypes "github.com/sentinel-official/hub/x/session/types"
 	"github.com/spf13/cobra"
 
+	clienttypes "github.com/sentinel-official/cli-client/types"
 	"github.com/sentinel-official/cli-client/x/session/types"
 )
 
@@ -104,6 +106,11 @@ func QuerySessions() *cobra.Command {
 				return err
 			}
 
+			paginate, err := clienttypes.NewPaginateOptionsFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
 			var (
 				items types.Sessions
 				qc    = sessiontypes.NewQueryServiceClient(ctx)
@@ -115,28 +122,46 @@ func QuerySessions() *cobra.Command {
 					return err
 				}
 
-				result, err := qc.QuerySessionsForAccount(
-					context.Background(),
-					sessiontypes.NewQuerySessionsForAccountRequest(
-						address,
-						pagination,
-					),
+				sessions, err := clienttypes.Paginate(cmd.Context(), pagination, paginate,
+					func(pagination *query.PageRequest) ([]sessiontypes.Session, *query.PageResponse, error) {
+						result, err := qc.QuerySessionsForAccount(
+							context.Background(),
+							sessiontypes.NewQuerySessionsForAccountRequest(
+								address,
+								pagination,
+							),
+						)
+						if err != nil {
+							return nil, nil, err
+						}
+
+						return result.Sessions, result.Pagination, nil
+					},
 				)
 				if err != nil {
 					return err
 				}
 
-				items = append(items, types.NewSessionsFromRaw(result.Sessions)...)
+				items = append(items, types.NewSessionsFromRaw(sessions)...)
 			} else {
-				result, err := qc.QuerySessions(
-					context.Background(),
-					sessiontypes.NewQuerySessionsRequest(pagination),
+				sessions, err := clienttypes.Paginate(cmd.Context(), pagination, paginate,
+					func(pagination *query.PageRequest) ([]sessiontypes.Session, *query.PageResponse, error) {
+						result, err := qc.QuerySessions(
+							context.Background(),
+							sessiontypes.NewQuerySessionsRequest(pagination),
+						)
+						if err != nil {
+							return nil, nil, err
+						}
+
+						return result.Sessions, result.Pagination, nil
+					},
 				)
 				if err != nil {
 					return err
 				}
 
-				items = append(items, types.NewSessionsFromRaw(result.Sessions)...)
+				items = append(items, types.NewSessionsFromRaw(sessions)...)
 			}
 
 			table := tablewriter.NewWriter(cmd.OutOrStdout())
@@ -163,6 +188,7 @@ func QuerySessions() *cobra.Command {
 
 	flags.AddQueryFlagsToCmd(cmd)
 	flags.AddPaginationFlagsToCmd(cmd, "sessions")
+	clienttypes.AddPaginateFlagsToCmd(cmd)
 
 	cmd.Flags().String(flagAddress, "", "filter with account address")
 	cmd.Flags().String(flagStatus, "Active", "filter with status (Active|Inactive)")
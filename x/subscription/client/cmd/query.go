@@ -7,11 +7,14 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/olekukonko/tablewriter"
 	subscriptiontypes "github.com/sentinel-official/hub/x/subscription/types"
 	"github.com/spf13/cobra"
 
+	clienttypes "github.com/sentinel-official/cli-client/types"
 	netutil "github.com/sentinel-official/cli-client/utils/net"
 	"github.com/sentinel-official/cli-client/x/subscription/types"
 )
@@ -120,6 +123,11 @@ func QuerySubscriptions() *cobra.Command {
 				return err
 			}
 
+			paginate, err := clienttypes.NewPaginateOptionsFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
 			var (
 				items types.Subscriptions
 				qsc   = subscriptiontypes.NewQueryServiceClient(ctx)
@@ -131,19 +139,28 @@ func QuerySubscriptions() *cobra.Command {
 					return err
 				}
 
-				result, err := qsc.QuerySubscriptionsForAccount(
-					context.Background(),
-					subscriptiontypes.NewQuerySubscriptionsForAccountRequest(
-						address,
-						pagination,
-					),
+				result, err := clienttypes.Paginate(cmd.Context(), pagination, paginate,
+					func(pagination *query.PageRequest) ([]*codectypes.Any, *query.PageResponse, error) {
+						result, err := qsc.QuerySubscriptionsForAccount(
+							context.Background(),
+							subscriptiontypes.NewQuerySubscriptionsForAccountRequest(
+								address,
+								pagination,
+							),
+						)
+						if err != nil {
+							return nil, nil, err
+						}
+
+						return result.Subscriptions, result.Pagination, nil
+					},
 				)
 				if err != nil {
 					return err
 				}
 
 				var subscriptions []subscriptiontypes.Subscription
-				for _, item := range result.Subscriptions {
+				for _, item := range result {
 					var subscription subscriptiontypes.Subscription
 					if err = ctx.InterfaceRegistry.UnpackAny(item, &subscription); err != nil {
 						return err
@@ -154,16 +171,25 @@ func QuerySubscriptions() *cobra.Command {
 
 				items = append(items, types.NewSubscriptionsFromRaw(subscriptions)...)
 			} else {
-				result, err := qsc.QuerySubscriptions(
-					context.Background(),
-					subscriptiontypes.NewQuerySubscriptionsRequest(pagination),
+				result, err := clienttypes.Paginate(cmd.Context(), pagination, paginate,
+					func(pagination *query.PageRequest) ([]*codectypes.Any, *query.PageResponse, error) {
+						result, err := qsc.QuerySubscriptions(
+							context.Background(),
+							subscriptiontypes.NewQuerySubscriptionsRequest(pagination),
+						)
+						if err != nil {
+							return nil, nil, err
+						}
+
+						return result.Subscriptions, result.Pagination, nil
+					},
 				)
 				if err != nil {
 					return err
 				}
 
 				var subscriptions []subscriptiontypes.Subscription
-				for _, item := range result.Subscriptions {
+				for _, item := range result {
 					var subscription subscriptiontypes.Subscription
 					if err = ctx.InterfaceRegistry.UnpackAny(item, &subscription); err != nil {
 						return err
@@ -202,6 +228,7 @@ func QuerySubscriptions() *cobra.Command {
 
 	flags.AddQueryFlagsToCmd(cmd)
 	flags.AddPaginationFlagsToCmd(cmd, "subscriptions")
+	clienttypes.AddPaginateFlagsToCmd(cmd)
 
 	cmd.Flags().String(flagAddress, "", "filter with account address")
 
@@ -289,23 +316,37 @@ func QueryAllocations() *cobra.Command {
 				return err
 			}
 
+			paginate, err := clienttypes.NewPaginateOptionsFromCmd(cmd)
+			if err != nil {
+				return err
+			}
+
 			var (
 				qsc = subscriptiontypes.NewQueryServiceClient(ctx)
 			)
 
-			result, err := qsc.QueryAllocations(
-				context.Background(),
-				subscriptiontypes.NewQueryAllocationsRequest(
-					id,
-					pagination,
-				),
+			allocations, err := clienttypes.Paginate(cmd.Context(), pagination, paginate,
+				func(pagination *query.PageRequest) ([]subscriptiontypes.Allocation, *query.PageResponse, error) {
+					result, err := qsc.QueryAllocations(
+						context.Background(),
+						subscriptiontypes.NewQueryAllocationsRequest(
+							id,
+							pagination,
+						),
+					)
+					if err != nil {
+						return nil, nil, err
+					}
+
+					return result.Allocations, result.Pagination, nil
+				},
 			)
 			if err != nil {
 				return err
 			}
 
 			var (
-				items = types.NewAllocationsFromRaw(result.Allocations)
+				items = types.NewAllocationsFromRaw(allocations)
 				table = tablewriter.NewWriter(cmd.OutOrStdout())
 			)
 
@@ -327,6 +368,7 @@ func QueryAllocations() *cobra.Command {
 
 	flags.AddQueryFlagsToCmd(cmd)
 	flags.AddPaginationFlagsToCmd(cmd, "allocations")
+	clienttypes.AddPaginateFlagsToCmd(cmd)
 
 	return cmd
 }